@@ -99,5 +99,5 @@ export default defineWorld({
     ...SALE_TABLES,
     ...PORTAL_TABLES,
   },
-  excludeSystems: ["SpawnSystem", "GachaSystem"], // registered as root systems
+  excludeSystems: ["SpawnSystem", "GachaSystem", "PostDeploySystem"], // registered as root systems
 });
@@ -0,0 +1,176 @@
+package calldata
+
+import (
+	"github.com/ftk/post-deploy/pkg/common"
+	gentile "github.com/ftk/post-deploy/pkg/gen-tile"
+	"github.com/ftk/post-deploy/pkg/table"
+	"go.uber.org/zap"
+)
+
+// the sections of the post deploy data, registered in build order
+func init() {
+	// map config and game config
+	Register(NewBuilder("gameConfig", "gameConfig.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildGameConfigData(l, ctx.DataConfig, ctx.MapColor, ctx.IsTest)
+	}))
+	Register(NewBuilder("achievement", "achievements.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		l.Infow("len Achievements", "value", len(ctx.DataConfig.Achievements))
+		return BuildAchievementData(l, ctx.DataConfig, 0)
+	}))
+	Register(NewBuilder("city", "map.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		callData, cities, err := BuildCityData(l, ctx.DataConfig)
+		ctx.Cities = cities
+		return callData, err
+	}))
+	// city level upgrade requirements
+	Register(NewBuilder("cityLevelRequire", "cityLevelRequires.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildCityLevelRequireData(l, ctx.DataConfig)
+	}))
+	Register(NewBuilder("npcShop", "map.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildNpcShopData(l, ctx.DataConfig)
+	}))
+	Register(NewBuilder("kingdom", "map.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildKingdomData(l, ctx.DataConfig)
+	}))
+	// kingdom economy ~ market fee (allied kingdoms included), crystal fee and king settings
+	Register(NewBuilder("kingdomEconomy", "map.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildKingdomEconomyData(l, ctx.DataConfig)
+	}))
+	// diplomacy ~ initial alliances
+	Register(NewBuilder("diplomacy", "map.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildDiplomacyData(l, ctx.DataConfig)
+	}))
+	Register(NewBuilder("item", "items.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildItemData(l, ctx.DataConfig, 0)
+	}))
+	// extra item info (equipment info, consumable info)
+	Register(NewBuilder("itemExtraInfo", "items.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildExtraItemInfoData(l, ctx.DataConfig, 0, false)
+	}))
+	Register(NewBuilder("itemRecipe", "itemRecipes.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildItemRecipeData(l, ctx.DataConfig, 0)
+	}))
+	// item exchange - collection exc
+	Register(NewBuilder("itemExchange", "itemExchanges.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildCollectionExcData(l, ctx.DataConfig, 0)
+	}))
+	// pet component info
+	Register(NewBuilder("petComponent", "petComponentRates.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildPetComponentData(l, ctx.DataConfig)
+	}))
+	Register(NewBuilder("gacha", "gachas.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildGachaData(l, ctx.DataConfig)
+	}))
+	Register(NewBuilder("salePackage", "salePackages.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildSalePackageData(l, ctx.DataConfig)
+	}))
+	Register(NewBuilder("welcomeConfig", "welcomeConfig.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		l.Infow("welcomeConfig", "value", ctx.DataConfig.WelcomeConfig)
+		welcomeConfigCallData, err := table.WelcomeConfigCallData(ctx.DataConfig.WelcomeConfig)
+		if err != nil {
+			l.Errorw("cannot build Welcome Config call data", "err", err)
+			return nil, err
+		}
+		return [][]byte{welcomeConfigCallData}, nil
+	}))
+	Register(NewBuilder("npc", "map.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildNpcData(l, ctx.DataConfig)
+	}))
+	Register(NewBuilder("quest", "quests.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildQuestData(l, ctx.DataConfig, 0)
+	}))
+	Register(NewBuilder("skill", "skills.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildSkillData(l, ctx.DataConfig, 0)
+	}))
+	Register(NewBuilder("monster", "monsters.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildMonsterData(l, ctx.DataConfig, 0, true)
+	}))
+	Register(NewBuilder("dailyQuestConfig", "quests.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		l.Infow("DailyQuestConfig", "value", ctx.DataConfig.DailyQuestConfig)
+		dailyQuestConfigData, err := table.DailyQuestConfigCallData(ctx.DataConfig.DailyQuestConfig)
+		if err != nil {
+			l.Errorw("cannot build DailyQuestConfig call data", "err", err)
+			return nil, err
+		}
+		return [][]byte{dailyQuestConfigData}, nil
+	}))
+	Register(NewBuilder("dropResource", "items.json", nil, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		minTier := 5 // drop from tier 5
+		if ctx.IsTest {
+			minTier = 2 // drop from tier 2
+		}
+		dropResourceCallData, err := table.DropResourceCallData(ctx.DataConfig, minTier)
+		if err != nil {
+			l.Errorw("cannot build DropResource call data", "err", err)
+			return nil, err
+		}
+		return [][]byte{dropResourceCallData}, nil
+	}))
+	// tile infos ~ also generates the monster locations, the cities need their tiles
+	Register(NewBuilder("tileInfo", "tileInfos.json", []string{"city"}, BuildTileInfoSection))
+	Register(NewBuilder("monsterLocation", "monsterLocationsCache.json", []string{"tileInfo"}, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildMonsterLocationData(l, ctx.MonsterLocations)
+	}))
+	// map overlays ~ city safe zones, blocked terrain and neutral tiles
+	Register(NewBuilder("mapOverlay", "mapColor.json", []string{"tileInfo"}, func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+		return BuildMapOverlayData(l, ctx.DataConfig, ctx.MapColor, ctx.TileInfos, ctx.MonsterLocations)
+	}))
+}
+
+// BuildTileInfoSection sets the tile infos and the monster locations of the context, the test data
+// is used as is while the real map is generated from the cache
+func BuildTileInfoSection(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+	dataConfig := ctx.DataConfig
+	var (
+		tileInfos        []common.TileInfo
+		monsterLocations []common.MonsterLocation
+	)
+	if ctx.IsTest {
+		// set data test
+		tileInfos = append(tileInfos, dataConfig.TileInfos...)
+		monsterLocations = append(monsterLocations, dataConfig.MonsterLocationsOverride...)
+		monsterLocations = append(monsterLocations, dataConfig.MonsterLocationsBoss...)
+	} else {
+		// regularMap := mapConfig[:4]
+		regularMap := ctx.MapConfig[:]
+		// random generate tile infos and resource location
+		tileInfos, monsterLocations = gentile.GenMapData(
+			dataConfig, regularMap, ctx.Cities, ctx.CacheTileInfos, ctx.CacheMonsterLocations,
+			dataConfig.MonsterLocationsOverride, dataConfig.MonsterLocationsBoss, dataConfig.Monsters)
+
+		// middle map ~ done comment now
+		// _, middleMonsterLocations := gentile.GenMapData(
+		// 	dataConfig, mapConfig[4:], cities, nil, nil, nil, dataConfig.MonsterLocationsBoss, dataConfig.Monsters)
+		// tileInfos = append(tileInfos, middleTileInfos...)
+		// monsterLocations = middleMonsterLocations
+		// monsterLocations = append(monsterLocations, middleMonsterLocations...)
+	}
+	// remove all kingdom id ~ because now player need to occupy the tile
+	for index := range tileInfos {
+		tileInfos[index].KingdomId = 0
+	}
+	// add city back to tile info
+	for _, city := range ctx.Cities {
+		tileInfos = append(tileInfos, common.TileInfo{
+			KingdomId:       city.KingdomId,
+			X:               city.X,
+			Y:               city.Y,
+			FarmSlot:        0,
+			ZoneType:        0,
+			ResourceItemIds: nil,
+		})
+	}
+	ctx.TileInfos = tileInfos
+	ctx.MonsterLocations = monsterLocations
+
+	callData := make([][]byte, 0, len(tileInfos))
+	for _, ti := range tileInfos {
+		tileInfoCallData, err := table.TileInfoCallData(ti, dataConfig)
+		if err != nil {
+			l.Errorw("cannot build TileInfo call data", "err", err)
+			return nil, err
+		}
+		callData = append(callData, tileInfoCallData)
+	}
+	return callData, nil
+}
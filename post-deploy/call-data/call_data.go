@@ -2,6 +2,7 @@ package calldata
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"sync"
@@ -38,6 +39,121 @@ func BuildKingdomData(l *zap.SugaredLogger, dataConfig common.DataConfig) ([][]b
 	return callData, nil
 }
 
+// BuildKingdomEconomyData sets the market fee matrix and king settings of kingdoms with an economy section,
+// either every kingdom or none has it. Allied kingdoms charge each other the fee of their alliance.
+func BuildKingdomEconomyData(l *zap.SugaredLogger, dataConfig common.DataConfig) ([][]byte, error) {
+	callData := make([][]byte, 0)
+	var kingdoms []common.Kingdom
+	for k, kingdom := range dataConfig.Kingdoms {
+		if k != strconv.FormatInt(int64(kingdom.Id), 10) {
+			l.Errorw("wrong kingdom key and id", "key", k, "id", kingdom.Id)
+			return nil, fmt.Errorf("wrong kingdom key and id %s %d", k, kingdom.Id)
+		}
+		if kingdom.Economy != nil {
+			kingdoms = append(kingdoms, kingdom)
+		}
+	}
+	if err := validateDiplomacy(dataConfig); err != nil {
+		l.Errorw("invalid diplomacy", "err", err)
+		return nil, err
+	}
+	allianceFees := allianceMarketFees(dataConfig)
+	if len(kingdoms) == 0 {
+		// no fee matrix, allied kingdoms still charge each other the alliance fee
+		pairs := make([][2]uint8, 0, len(allianceFees))
+		for pair := range allianceFees {
+			pairs = append(pairs, pair)
+		}
+		sort.Slice(pairs, func(i, j int) bool {
+			if pairs[i][0] != pairs[j][0] {
+				return pairs[i][0] < pairs[j][0]
+			}
+			return pairs[i][1] < pairs[j][1]
+		})
+		for _, pair := range pairs {
+			marketFeeCallData, err := table.MarketFeeCallData(pair[0], pair[1], allianceFees[pair])
+			if err != nil {
+				l.Errorw("cannot build MarketFee call data", "err", err)
+				return nil, err
+			}
+			callData = append(callData, marketFeeCallData)
+		}
+		return callData, nil
+	}
+	if err := validateMarketFeeMatrix(dataConfig); err != nil {
+		l.Errorw("invalid market fee matrix", "err", err)
+		return nil, err
+	}
+	sort.Slice(kingdoms, func(i, j int) bool {
+		return kingdoms[i].Id < kingdoms[j].Id
+	})
+	for _, kingdom := range kingdoms {
+		for _, other := range kingdoms {
+			fee := kingdom.Economy.MarketFees[strconv.Itoa(int(other.Id))]
+			if allianceFee, ok := allianceFees[[2]uint8{kingdom.Id, other.Id}]; ok {
+				fee = allianceFee
+			}
+			marketFeeCallData, err := table.MarketFeeCallData(kingdom.Id, other.Id, fee)
+			if err != nil {
+				l.Errorw("cannot build MarketFee call data", "err", err)
+				return nil, err
+			}
+			callData = append(callData, marketFeeCallData)
+		}
+		crystalFeeCallData, err := table.CrystalFeeCallData(kingdom.Id, kingdom.Economy.CrystalFee)
+		if err != nil {
+			l.Errorw("cannot build CrystalFee call data", "err", err)
+			return nil, err
+		}
+		callData = append(callData, crystalFeeCallData)
+		kingSettingCallData, err := table.KingSettingCallData(kingdom.Id, *kingdom.Economy)
+		if err != nil {
+			l.Errorw("cannot build KingSetting call data", "err", err)
+			return nil, err
+		}
+		callData = append(callData, kingSettingCallData)
+		kingSetting2CallData, err := table.KingSetting2CallData(kingdom.Id, *kingdom.Economy)
+		if err != nil {
+			l.Errorw("cannot build KingSetting2 call data", "err", err)
+			return nil, err
+		}
+		callData = append(callData, kingSetting2CallData)
+	}
+	return callData, nil
+}
+
+// validateMarketFeeMatrix checks that every kingdom has a fee for every kingdom, itself included,
+// and that two kingdoms charge each other the same fee
+func validateMarketFeeMatrix(dataConfig common.DataConfig) error {
+	for _, kingdom := range dataConfig.Kingdoms {
+		if kingdom.Economy == nil {
+			return fmt.Errorf("kingdom %d has no economy", kingdom.Id)
+		}
+		for k := range kingdom.Economy.MarketFees {
+			if _, ok := dataConfig.Kingdoms[k]; !ok {
+				return fmt.Errorf("kingdom %d: market fee for unknown kingdom %s", kingdom.Id, k)
+			}
+		}
+	}
+	for _, kingdom := range dataConfig.Kingdoms {
+		for k, other := range dataConfig.Kingdoms {
+			fee, ok := kingdom.Economy.MarketFees[k]
+			if !ok {
+				return fmt.Errorf("kingdom %d: missing market fee for kingdom %d", kingdom.Id, other.Id)
+			}
+			otherFee, ok := other.Economy.MarketFees[strconv.Itoa(int(kingdom.Id))]
+			if !ok {
+				return fmt.Errorf("kingdom %d: missing market fee for kingdom %d", other.Id, kingdom.Id)
+			}
+			if fee != otherFee {
+				return fmt.Errorf("market fee of kingdom %d => %d is %d but %d => %d is %d",
+					kingdom.Id, other.Id, fee, other.Id, kingdom.Id, otherFee)
+			}
+		}
+	}
+	return nil
+}
+
 func BuildCityData(l *zap.SugaredLogger, dataConfig common.DataConfig) ([][]byte, []common.City, error) {
 	callData := make([][]byte, 0)
 	l.Infow("len Cities", "value", len(dataConfig.Cities))
@@ -94,10 +210,84 @@ func BuildNpcShopData(l *zap.SugaredLogger, dataConfig common.DataConfig) ([][]b
 			return nil, err
 		}
 		callData = append(callData, npcShopData)
+		if err := validateNpcShopStock(dataConfig, city); err != nil {
+			l.Errorw("invalid npc shop stock", "cityId", city.Id, "err", err)
+			return nil, err
+		}
+		for _, stock := range city.ShopStock {
+			npcShopInventoryData, err := table.NpcShopInventoryCallData(city.Id, stock)
+			if err != nil {
+				l.Errorw("cannot build npcShopInventory call data", "err", err)
+				return nil, err
+			}
+			callData = append(callData, npcShopInventoryData)
+		}
+	}
+	return callData, nil
+}
+
+// BuildNpcShopRestockData resets the npc shop stock that has the always restock policy
+func BuildNpcShopRestockData(l *zap.SugaredLogger, dataConfig common.DataConfig) ([][]byte, error) {
+	callData := make([][]byte, 0)
+	var cities []common.City
+	for _, city := range dataConfig.Cities {
+		cities = append(cities, city)
+	}
+	sort.Slice(cities, func(i, j int) bool {
+		return cities[i].Id < cities[j].Id
+	})
+	for _, city := range cities {
+		if err := validateNpcShopStock(dataConfig, city); err != nil {
+			l.Errorw("invalid npc shop stock", "cityId", city.Id, "err", err)
+			return nil, err
+		}
+		for _, stock := range city.ShopStock {
+			if stock.Restock != common.NpcShopRestockAlways {
+				continue
+			}
+			npcShopInventoryData, err := table.NpcShopInventoryCallData(city.Id, stock)
+			if err != nil {
+				l.Errorw("cannot build npcShopInventory call data", "err", err)
+				return nil, err
+			}
+			callData = append(callData, npcShopInventoryData)
+		}
 	}
 	return callData, nil
 }
 
+// npcShopItemBalanceCap is NPC_ITEM_BALANCE_CAP in NpcShopSystem
+const npcShopItemBalanceCap = 200
+
+// validateNpcShopStock checks that npc shops only stock existing Other items under the balance cap
+func validateNpcShopStock(dataConfig common.DataConfig, city common.City) error {
+	otherCategory := dataConfig.ItemCategoryTypes["Other"]
+	seen := make(map[int]bool)
+	for _, stock := range city.ShopStock {
+		item, ok := dataConfig.Items[strconv.Itoa(stock.ItemId)]
+		if !ok {
+			return fmt.Errorf("city %d: stock item %d does not exist", city.Id, stock.ItemId)
+		}
+		if item.Category != otherCategory {
+			return fmt.Errorf("city %d: stock item %d is not in the Other category", city.Id, stock.ItemId)
+		}
+		if seen[stock.ItemId] {
+			return fmt.Errorf("city %d: duplicated stock item %d", city.Id, stock.ItemId)
+		}
+		seen[stock.ItemId] = true
+		if stock.Amount == 0 || stock.Amount > npcShopItemBalanceCap {
+			return fmt.Errorf("city %d: stock item %d amount %d out of range [1, %d]",
+				city.Id, stock.ItemId, stock.Amount, npcShopItemBalanceCap)
+		}
+		switch stock.Restock {
+		case common.NpcShopRestockInitial, common.NpcShopRestockAlways:
+		default:
+			return fmt.Errorf("city %d: stock item %d has unknown restock policy %q", city.Id, stock.ItemId, stock.Restock)
+		}
+	}
+	return nil
+}
+
 func BuildItemData(l *zap.SugaredLogger, dataConfig common.DataConfig, fromItemID int) ([][]byte, error) {
 	callData := make([][]byte, 0)
 	l.Infow("len Items", "value", len(dataConfig.Items))
@@ -184,6 +374,8 @@ func BuildExtraItemInfoData(l *zap.SugaredLogger, dataConfig common.DataConfig,
 	sort.Slice(items, func(i, j int) bool {
 		return items[i].Id < items[j].Id
 	})
+	cardItemType, hasCardType := dataConfig.ItemTypes["Card"]
+	statModifierItemType, hasStatModifierType := dataConfig.ItemTypes["StatModifierItem"]
 	once := sync.Once{}
 	for _, item := range items {
 		if item.Id < fromItemID {
@@ -192,6 +384,22 @@ func BuildExtraItemInfoData(l *zap.SugaredLogger, dataConfig common.DataConfig,
 		once.Do(func() {
 			l.Infow("Extra Item Info starts from ID", "value", fromItemID)
 		})
+		if hasCardType && item.Type == cardItemType && item.CardInfo == nil {
+			l.Errorw("card item has no card info", "itemId", item.Id)
+			return nil, fmt.Errorf("card item %d has no card info", item.Id)
+		}
+		if item.CardInfo != nil && (!hasCardType || item.Type != cardItemType) {
+			l.Errorw("card info on non card item", "itemId", item.Id, "type", item.Type)
+			return nil, fmt.Errorf("item %d has card info but type %d is not Card", item.Id, item.Type)
+		}
+		if hasStatModifierType && item.Type == statModifierItemType && item.StatModifierInfo == nil {
+			l.Errorw("stat modifier item has no stat modifier info", "itemId", item.Id)
+			return nil, fmt.Errorf("stat modifier item %d has no stat modifier info", item.Id)
+		}
+		if item.StatModifierInfo != nil && (!hasStatModifierType || item.Type != statModifierItemType) {
+			l.Errorw("stat modifier info on non stat modifier item", "itemId", item.Id, "type", item.Type)
+			return nil, fmt.Errorf("item %d has stat modifier info but type %d is not StatModifierItem", item.Id, item.Type)
+		}
 		if equipmentOnly && item.Category != 1 {
 			continue
 		}
@@ -219,6 +427,14 @@ func BuildExtraItemInfoData(l *zap.SugaredLogger, dataConfig common.DataConfig,
 				return nil, err
 			}
 			callData = append(callData, healingItemInfoCallData)
+		case item.StatModifierInfo != nil:
+			l.Infow("stat modifier info", "value", item.StatModifierInfo)
+			statModifierInfoCallData, err := table.StatModifierItemInfoCallData(*item.StatModifierInfo, item.Id)
+			if err != nil {
+				l.Errorw("cannot build Stat Modifier Item Info call data", "err", err)
+				return nil, err
+			}
+			callData = append(callData, statModifierInfoCallData)
 		case item.ResourceInfo != nil:
 			// l.Infow("resource info", "value", item.ResourceInfo)
 			resourceItemInfoCallData, err := table.ResourceItemInfoCallData(*item.ResourceInfo, item.Id)
@@ -227,6 +443,13 @@ func BuildExtraItemInfoData(l *zap.SugaredLogger, dataConfig common.DataConfig,
 				return nil, err
 			}
 			callData = append(callData, resourceItemInfoCallData)
+		case item.CardInfo != nil:
+			cardInfoCallData, err := table.CardInfoCallData(*item.CardInfo, item.Id)
+			if err != nil {
+				l.Errorw("cannot build Card Info call data", "err", err)
+				return nil, err
+			}
+			callData = append(callData, cardInfoCallData)
 		case item.SkinInfo != nil:
 			l.Infow("skin info", "value", item.SkinInfo)
 			skinItemInfoCallData, err := table.SkinInfoCallData(item)
@@ -337,14 +560,6 @@ func BuildNpcData(l *zap.SugaredLogger, dataConfig common.DataConfig) ([][]byte,
 			return nil, err
 		}
 		callData = append(callData, npcCallData)
-		// if len(npc.Cards) != 0 {
-		// 	npcCardCallData, err := table.NpcCardCallData(npc)
-		// 	if err != nil {
-		// 		l.Errorw("cannot build NPC Card call data", "err", err)
-		// 		return nil, err
-		// 	}
-		// 	callData = append(callData, npcCardCallData)
-		// }
 	}
 	return callData, nil
 }
@@ -380,10 +595,12 @@ func BuildQuestData(l *zap.SugaredLogger, dataConfig common.DataConfig, fromQues
 			quest.QuestType = dataConfig.QuestTypes[common.QuestContribute]
 		}
 		if _, ok := dataConfig.Npcs[strconv.FormatInt(quest.FromNpcId, 10)]; !ok {
-			l.Panicw("invalid from npc id", "id", quest.Id)
+			l.Errorw("invalid from npc id", "id", quest.Id)
+			return nil, fmt.Errorf("quest %d: from npc %d does not exist", quest.Id, quest.FromNpcId)
 		}
 		if _, ok := dataConfig.Npcs[strconv.FormatInt(quest.ToNpcId, 10)]; !ok {
-			l.Panicw("invalid to npc id", "id", quest.Id)
+			l.Errorw("invalid to npc id", "id", quest.Id)
+			return nil, fmt.Errorf("quest %d: to npc %d does not exist", quest.Id, quest.ToNpcId)
 		}
 		questCallData, err := table.QuestCallData(quest)
 		if err != nil {
@@ -603,7 +820,15 @@ func BuildResourceDropData(l *zap.SugaredLogger, dataConfig common.DataConfig) (
 
 func BuildCollectionExcData(l *zap.SugaredLogger, dataConfig common.DataConfig, fromItemExchangeID int) ([][]byte, error) {
 	callData := make([][]byte, 0)
+	// sort by itemId so that two builds of the same config are identical
+	itemExs := make([]common.ItemExchange, 0, len(dataConfig.ItemExchanges))
 	for _, itemEx := range dataConfig.ItemExchanges {
+		itemExs = append(itemExs, itemEx)
+	}
+	sort.Slice(itemExs, func(i, j int) bool {
+		return itemExs[i].ItemId < itemExs[j].ItemId
+	})
+	for _, itemEx := range itemExs {
 		if itemEx.ItemId < fromItemExchangeID {
 			continue
 		}
@@ -619,7 +844,15 @@ func BuildCollectionExcData(l *zap.SugaredLogger, dataConfig common.DataConfig,
 
 func BuildPetComponentData(l *zap.SugaredLogger, dataConfig common.DataConfig) ([][]byte, error) {
 	callData := make([][]byte, 0)
+	// sort by petItemId so that two builds of the same config are identical
+	petCpnInfos := make([]common.PetCpnRate, 0, len(dataConfig.PetComponentRates))
 	for _, petCpnInfo := range dataConfig.PetComponentRates {
+		petCpnInfos = append(petCpnInfos, petCpnInfo)
+	}
+	sort.Slice(petCpnInfos, func(i, j int) bool {
+		return petCpnInfos[i].PetItemId < petCpnInfos[j].PetItemId
+	})
+	for _, petCpnInfo := range petCpnInfos {
 		for _, eCpn := range petCpnInfo.Components {
 			petCpnCallData, err := table.PetComponentInfoCallData(petCpnInfo.PetItemId, eCpn)
 			if err != nil {
@@ -631,3 +864,255 @@ func BuildPetComponentData(l *zap.SugaredLogger, dataConfig common.DataConfig) (
 	}
 	return callData, nil
 }
+
+func BuildGachaData(l *zap.SugaredLogger, dataConfig common.DataConfig) ([][]byte, error) {
+	callData := make([][]byte, 0)
+	l.Infow("len Gachas", "value", len(dataConfig.Gachas))
+	// banners of scheduled events are opened by the events command
+	scheduled := make(map[int]bool)
+	for _, event := range dataConfig.Events {
+		if event.Type == common.EventTypeGacha {
+			scheduled[event.GachaId] = true
+		}
+	}
+	var gachas []common.Gacha
+	for k, gacha := range dataConfig.Gachas {
+		if k != strconv.FormatInt(int64(gacha.Id), 10) {
+			l.Errorw("wrong gacha key and id", "key", k, "id", gacha.Id)
+			return nil, fmt.Errorf("wrong gacha key and id %s %d", k, gacha.Id)
+		}
+		if scheduled[gacha.Id] {
+			l.Infow("skip scheduled gacha", "id", gacha.Id)
+			continue
+		}
+		gachas = append(gachas, gacha)
+	}
+	sort.Slice(gachas, func(i, j int) bool {
+		return gachas[i].Id < gachas[j].Id
+	})
+	for _, gacha := range gachas {
+		if err := validateGacha(dataConfig, gacha); err != nil {
+			l.Errorw("invalid gacha", "gachaId", gacha.Id, "err", err)
+			return nil, err
+		}
+		gachaCallData, err := table.GachaV5CallData(gacha)
+		if err != nil {
+			l.Errorw("cannot build GachaV5 call data", "err", err)
+			return nil, err
+		}
+		callData = append(callData, gachaCallData)
+	}
+	return callData, nil
+}
+
+// validateGacha checks that the gacha items exist and the ticket item is a GachaTicket,
+// percents are checked by the encoder
+func validateGacha(dataConfig common.DataConfig, gacha common.Gacha) error {
+	seen := make(map[int]bool)
+	for _, gachaItem := range gacha.Items {
+		if _, ok := dataConfig.Items[strconv.Itoa(gachaItem.ItemId)]; !ok {
+			return fmt.Errorf("gacha %d: item %d does not exist", gacha.Id, gachaItem.ItemId)
+		}
+		if seen[gachaItem.ItemId] {
+			return fmt.Errorf("gacha %d: duplicated item %d", gacha.Id, gachaItem.ItemId)
+		}
+		seen[gachaItem.ItemId] = true
+	}
+	if gacha.TicketItemId == 0 {
+		return nil
+	}
+	ticketItemType, ok := dataConfig.ItemTypes["GachaTicket"]
+	if !ok {
+		return fmt.Errorf("item type GachaTicket is not defined")
+	}
+	ticketItem, ok := dataConfig.Items[strconv.Itoa(gacha.TicketItemId)]
+	if !ok {
+		return fmt.Errorf("gacha %d: ticket item %d does not exist", gacha.Id, gacha.TicketItemId)
+	}
+	if ticketItem.Type != ticketItemType {
+		return fmt.Errorf("gacha %d: ticket item %d is not a GachaTicket", gacha.Id, gacha.TicketItemId)
+	}
+	return nil
+}
+
+// BuildGameConfigData builds the singleton config tables, MapConfig bounds are the full_map rectangle of mapColor.
+// The test map stays unbounded, the Foundry tests move characters anywhere.
+func BuildGameConfigData(
+	l *zap.SugaredLogger, dataConfig common.DataConfig, mapColor common.MapColor, isTest bool) ([][]byte, error) {
+	callData := make([][]byte, 0)
+	width, height := uint32(math.MaxUint32), uint32(math.MaxUint32)
+	if !isTest {
+		var err error
+		width, height, err = mapSize(mapColor.FullMap)
+		if err != nil {
+			l.Errorw("invalid full map", "err", err)
+			return nil, err
+		}
+	}
+	l.Infow("map size", "width", width, "height", height)
+	mapConfigCallData, err := table.MapConfigCallData(width, height)
+	if err != nil {
+		l.Errorw("cannot build MapConfig call data", "err", err)
+		return nil, err
+	}
+	callData = append(callData, mapConfigCallData)
+	gameConfig := dataConfig.GameConfig
+	if gameConfig.Inventory != nil {
+		inventoryConfigCallData, err := table.InventoryConfigCallData(*gameConfig.Inventory)
+		if err != nil {
+			l.Errorw("cannot build InventoryConfig call data", "err", err)
+			return nil, err
+		}
+		callData = append(callData, inventoryConfigCallData)
+	}
+	if gameConfig.Movement != nil {
+		movementConfigCallData, err := table.MovementConfigCallData(*gameConfig.Movement)
+		if err != nil {
+			l.Errorw("cannot build MovementConfig call data", "err", err)
+			return nil, err
+		}
+		callData = append(callData, movementConfigCallData)
+	}
+	if gameConfig.ExpAmp != nil {
+		expAmpConfigCallData, err := table.ExpAmpConfigCallData(*gameConfig.ExpAmp)
+		if err != nil {
+			l.Errorw("cannot build ExpAmpConfig call data", "err", err)
+			return nil, err
+		}
+		callData = append(callData, expAmpConfigCallData)
+	}
+	return callData, nil
+}
+
+// mapSize returns the number of tiles on each axis of an axis aligned rectangle, corners are inclusive
+func mapSize(corners [4]common.Location) (uint32, uint32, error) {
+	minX, maxX, minY, maxY := corners[0].X, corners[0].X, corners[0].Y, corners[0].Y
+	for _, corner := range corners {
+		minX, maxX = min(minX, corner.X), max(maxX, corner.X)
+		minY, maxY = min(minY, corner.Y), max(maxY, corner.Y)
+	}
+	for _, corner := range corners {
+		if (corner.X != minX && corner.X != maxX) || (corner.Y != minY && corner.Y != maxY) {
+			return 0, 0, fmt.Errorf("full map corner %v is not on the rectangle [%d, %d]x[%d, %d]", corner, minX, maxX, minY, maxY)
+		}
+	}
+	seen := make(map[common.Location]bool)
+	for _, corner := range corners {
+		seen[corner] = true
+	}
+	if len(seen) != 4 {
+		return 0, 0, fmt.Errorf("full map corners %v are not a rectangle", corners)
+	}
+	return uint32(int64(maxX) - int64(minX) + 1), uint32(int64(maxY) - int64(minY) + 1), nil
+}
+
+func BuildCityLevelRequireData(l *zap.SugaredLogger, dataConfig common.DataConfig) ([][]byte, error) {
+	callData := make([][]byte, 0)
+	l.Infow("len CityLevelRequires", "value", len(dataConfig.CityLevelRequires))
+	var requires []common.CityLevelRequire
+	for k, require := range dataConfig.CityLevelRequires {
+		if k != strconv.FormatInt(int64(require.Level), 10) {
+			l.Errorw("wrong city level require key and level", "key", k, "level", require.Level)
+			return nil, fmt.Errorf("wrong city level require key and level %s %d", k, require.Level)
+		}
+		requires = append(requires, require)
+	}
+	sort.Slice(requires, func(i, j int) bool {
+		return requires[i].Level < requires[j].Level
+	})
+	for _, require := range requires {
+		if err := validateCityLevelRequire(dataConfig, require); err != nil {
+			l.Errorw("invalid city level require", "level", require.Level, "err", err)
+			return nil, err
+		}
+		requireCallData, err := table.CResourceRequireCallData(require)
+		if err != nil {
+			l.Errorw("cannot build CResourceRequire call data", "err", err)
+			return nil, err
+		}
+		callData = append(callData, requireCallData)
+	}
+	return callData, nil
+}
+
+// validateCityLevelRequire checks that every required resource is an existing item with ResourceInfo
+func validateCityLevelRequire(dataConfig common.DataConfig, require common.CityLevelRequire) error {
+	seen := make(map[int]bool)
+	for _, resource := range require.Resources {
+		item, ok := dataConfig.Items[strconv.Itoa(resource.ItemId)]
+		if !ok {
+			return fmt.Errorf("city level %d: resource %d does not exist", require.Level, resource.ItemId)
+		}
+		if item.ResourceInfo == nil {
+			return fmt.Errorf("city level %d: item %d has no resourceInfo", require.Level, resource.ItemId)
+		}
+		if seen[resource.ItemId] {
+			return fmt.Errorf("city level %d: duplicated resource %d", require.Level, resource.ItemId)
+		}
+		seen[resource.ItemId] = true
+	}
+	return nil
+}
+
+func BuildSalePackageData(l *zap.SugaredLogger, dataConfig common.DataConfig) ([][]byte, error) {
+	callData := make([][]byte, 0)
+	l.Infow("len SalePackages", "value", len(dataConfig.SalePackages))
+	var salePackages []common.SalePackage
+	for k, salePackage := range dataConfig.SalePackages {
+		if k != strconv.FormatInt(int64(salePackage.Id), 10) {
+			l.Errorw("wrong sale package key and id", "key", k, "id", salePackage.Id)
+			return nil, fmt.Errorf("wrong sale package key and id %s %d", k, salePackage.Id)
+		}
+		salePackages = append(salePackages, salePackage)
+	}
+	sort.Slice(salePackages, func(i, j int) bool {
+		return salePackages[i].Id < salePackages[j].Id
+	})
+	for _, salePackage := range salePackages {
+		if err := validateSalePackage(dataConfig, salePackage); err != nil {
+			l.Errorw("invalid sale package", "id", salePackage.Id, "err", err)
+			return nil, err
+		}
+		salePackageCallData, err := table.SalePackageV2CallData(salePackage)
+		if err != nil {
+			l.Errorw("cannot build SalePackageV2 call data", "err", err)
+			return nil, err
+		}
+		callData = append(callData, salePackageCallData)
+	}
+	return callData, nil
+}
+
+// validateSalePackage checks that the bonus items and the achievements exist,
+// the amounts are checked by the encoder
+func validateSalePackage(dataConfig common.DataConfig, salePackage common.SalePackage) error {
+	if len(salePackage.ItemIds) != len(salePackage.ItemAmounts) {
+		return fmt.Errorf("sale package %d: %d itemIds but %d itemAmounts",
+			salePackage.Id, len(salePackage.ItemIds), len(salePackage.ItemAmounts))
+	}
+	seenItems := make(map[int]bool)
+	for _, itemId := range salePackage.ItemIds {
+		item, ok := dataConfig.Items[strconv.Itoa(itemId)]
+		if !ok {
+			return fmt.Errorf("sale package %d: item %d does not exist", salePackage.Id, itemId)
+		}
+		if salePackage.RequireTradable && item.Untradable {
+			return fmt.Errorf("sale package %d: item %d is untradable", salePackage.Id, itemId)
+		}
+		if seenItems[itemId] {
+			return fmt.Errorf("sale package %d: duplicated item %d", salePackage.Id, itemId)
+		}
+		seenItems[itemId] = true
+	}
+	seenAchievements := make(map[int]bool)
+	for _, achievementId := range salePackage.AchievementIds {
+		if _, ok := dataConfig.Achievements[strconv.Itoa(achievementId)]; !ok {
+			return fmt.Errorf("sale package %d: achievement %d does not exist", salePackage.Id, achievementId)
+		}
+		if seenAchievements[achievementId] {
+			return fmt.Errorf("sale package %d: duplicated achievement %d", salePackage.Id, achievementId)
+		}
+		seenAchievements[achievementId] = true
+	}
+	return nil
+}
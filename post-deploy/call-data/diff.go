@@ -0,0 +1,27 @@
+package calldata
+
+import (
+	"github.com/ftk/post-deploy/pkg/mud"
+	"go.uber.org/zap"
+)
+
+// BuildDeltaData builds the selected sections for both snapshots and returns the records
+// to patch so that a world deployed with oldCtx matches newCtx
+func BuildDeltaData(l *zap.SugaredLogger, oldCtx, newCtx *BuildContext, only, skip []string) ([]mud.RecordChange, error) {
+	oldCallData, err := BuildSections(l.With("snapshot", "old"), oldCtx, only, skip)
+	if err != nil {
+		l.Errorw("cannot build old snapshot", "err", err)
+		return nil, err
+	}
+	newCallData, err := BuildSections(l.With("snapshot", "new"), newCtx, only, skip)
+	if err != nil {
+		l.Errorw("cannot build new snapshot", "err", err)
+		return nil, err
+	}
+	changes, err := mud.DiffCalldata(oldCallData, newCallData)
+	if err != nil {
+		l.Errorw("cannot diff snapshots", "err", err)
+		return nil, err
+	}
+	return changes, nil
+}
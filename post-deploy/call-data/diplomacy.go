@@ -0,0 +1,61 @@
+package calldata
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/ftk/post-deploy/pkg/table"
+	"go.uber.org/zap"
+)
+
+// BuildDiplomacyData seeds the alliances of map.json, their market fees are written by BuildKingdomEconomyData
+func BuildDiplomacyData(l *zap.SugaredLogger, dataConfig common.DataConfig) ([][]byte, error) {
+	callData := make([][]byte, 0)
+	l.Infow("len Alliances", "value", len(dataConfig.Diplomacy.Alliances))
+	if err := validateDiplomacy(dataConfig); err != nil {
+		l.Errorw("invalid diplomacy", "err", err)
+		return nil, err
+	}
+	for _, alliance := range dataConfig.Diplomacy.Alliances {
+		allianceCallData, err := table.AllianceV2CallData(alliance.KingdomA, alliance.KingdomB)
+		if err != nil {
+			l.Errorw("cannot build AllianceV2 call data", "err", err)
+			return nil, err
+		}
+		callData = append(callData, allianceCallData...)
+	}
+	return callData, nil
+}
+
+// allianceMarketFees returns the market fees set by alliances for both key orders
+func allianceMarketFees(dataConfig common.DataConfig) map[[2]uint8]uint8 {
+	fees := make(map[[2]uint8]uint8)
+	for _, alliance := range dataConfig.Diplomacy.Alliances {
+		if alliance.MarketFee != nil {
+			fees[[2]uint8{alliance.KingdomA, alliance.KingdomB}] = *alliance.MarketFee
+			fees[[2]uint8{alliance.KingdomB, alliance.KingdomA}] = *alliance.MarketFee
+		}
+	}
+	return fees
+}
+
+func validateDiplomacy(dataConfig common.DataConfig) error {
+	allied := make(map[[2]uint8]bool)
+	for _, alliance := range dataConfig.Diplomacy.Alliances {
+		for _, kingdomId := range []uint8{alliance.KingdomA, alliance.KingdomB} {
+			if _, ok := dataConfig.Kingdoms[strconv.Itoa(int(kingdomId))]; !ok {
+				return fmt.Errorf("alliance %d - %d: kingdom %d does not exist", alliance.KingdomA, alliance.KingdomB, kingdomId)
+			}
+		}
+		if alliance.KingdomA == alliance.KingdomB {
+			return fmt.Errorf("alliance %d - %d: a kingdom cannot be allied with itself", alliance.KingdomA, alliance.KingdomB)
+		}
+		if allied[[2]uint8{alliance.KingdomA, alliance.KingdomB}] {
+			return fmt.Errorf("alliance %d - %d is duplicated", alliance.KingdomA, alliance.KingdomB)
+		}
+		allied[[2]uint8{alliance.KingdomA, alliance.KingdomB}] = true
+		allied[[2]uint8{alliance.KingdomB, alliance.KingdomA}] = true
+	}
+	return nil
+}
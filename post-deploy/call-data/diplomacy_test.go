@@ -0,0 +1,97 @@
+package calldata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/ftk/post-deploy/pkg/mud"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBuildKingdomEconomyDataAllianceFees(t *testing.T) {
+	allianceFee := uint8(1)
+	economy := func(fees map[string]uint8) *common.KingdomEconomy {
+		return &common.KingdomEconomy{MarketFees: fees}
+	}
+	marketFees := func(t *testing.T, dataConfig common.DataConfig) []string {
+		callData, err := BuildKingdomEconomyData(zap.S(), dataConfig)
+		require.NoError(t, err)
+		var fees []string
+		for _, data := range callData {
+			decoded, err := mud.DecodeCalldata(data)
+			require.NoError(t, err)
+			if strings.Contains(decoded.String(), "app:MarketFee ") {
+				fees = append(fees, decoded.String())
+			}
+		}
+		return fees
+	}
+	alliances := common.Diplomacy{Alliances: []common.Alliance{
+		{KingdomA: 1, KingdomB: 2, MarketFee: &allianceFee},
+		{KingdomA: 1, KingdomB: 3},
+	}}
+
+	t.Run("allied kingdoms charge the alliance fee", func(t *testing.T) {
+		dataConfig := common.DataConfig{
+			Kingdoms: map[string]common.Kingdom{
+				"1": {Id: 1, Economy: economy(map[string]uint8{"1": 0, "2": 5, "3": 5})},
+				"2": {Id: 2, Economy: economy(map[string]uint8{"1": 5, "2": 0, "3": 5})},
+				"3": {Id: 3, Economy: economy(map[string]uint8{"1": 5, "2": 5, "3": 0})},
+			},
+			Diplomacy: alliances,
+		}
+		got := marketFees(t, dataConfig)
+		require.Len(t, got, 9)
+		require.Equal(t, "setRecord app:MarketFee (kingdomAId=1, kingdomBId=2) fee=1", got[1])
+		require.Equal(t, "setRecord app:MarketFee (kingdomAId=1, kingdomBId=3) fee=5", got[2])
+		require.Equal(t, "setRecord app:MarketFee (kingdomAId=2, kingdomBId=1) fee=1", got[3])
+	})
+
+	t.Run("without economy only the alliance fees", func(t *testing.T) {
+		dataConfig := common.DataConfig{
+			Kingdoms:  map[string]common.Kingdom{"1": {Id: 1}, "2": {Id: 2}, "3": {Id: 3}},
+			Diplomacy: alliances,
+		}
+		require.Equal(t, []string{
+			"setRecord app:MarketFee (kingdomAId=1, kingdomBId=2) fee=1",
+			"setRecord app:MarketFee (kingdomAId=2, kingdomBId=1) fee=1",
+		}, marketFees(t, dataConfig))
+	})
+
+	t.Run("invalid alliances are rejected on both paths", func(t *testing.T) {
+		for _, tt := range []struct {
+			name      string
+			alliances []common.Alliance
+			wantErr   string
+		}{
+			{
+				name:      "unknown kingdom",
+				alliances: []common.Alliance{{KingdomA: 1, KingdomB: 4, MarketFee: &allianceFee}},
+				wantErr:   "alliance 1 - 4: kingdom 4 does not exist",
+			},
+			{
+				name:      "self alliance",
+				alliances: []common.Alliance{{KingdomA: 2, KingdomB: 2, MarketFee: &allianceFee}},
+				wantErr:   "alliance 2 - 2: a kingdom cannot be allied with itself",
+			},
+		} {
+			withoutEconomy := common.DataConfig{
+				Kingdoms:  map[string]common.Kingdom{"1": {Id: 1}, "2": {Id: 2}},
+				Diplomacy: common.Diplomacy{Alliances: tt.alliances},
+			}
+			withEconomy := common.DataConfig{
+				Kingdoms: map[string]common.Kingdom{
+					"1": {Id: 1, Economy: economy(map[string]uint8{"1": 0, "2": 5})},
+					"2": {Id: 2, Economy: economy(map[string]uint8{"1": 5, "2": 0})},
+				},
+				Diplomacy: common.Diplomacy{Alliances: tt.alliances},
+			}
+			for _, dataConfig := range []common.DataConfig{withoutEconomy, withEconomy} {
+				_, err := BuildKingdomEconomyData(zap.S(), dataConfig)
+				require.EqualError(t, err, tt.wantErr, tt.name)
+			}
+		}
+	})
+}
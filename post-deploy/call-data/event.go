@@ -0,0 +1,149 @@
+package calldata
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/ftk/post-deploy/pkg/table"
+	"go.uber.org/zap"
+)
+
+const (
+	EventPhaseStart = "start"
+	EventPhaseEnd   = "end"
+)
+
+// EventCallData is the calldata to send at Time for the start or the end of an event
+type EventCallData struct {
+	Time     int64
+	EventId  int
+	Phase    string
+	CallData [][]byte
+}
+
+// BuildEventData builds the calldata of every event start and end, sorted by time with ends first.
+// Events changing the same record must not overlap: ExpAmpConfig is a singleton
+// and a gacha banner can only be in one event at a time.
+func BuildEventData(l *zap.SugaredLogger, dataConfig common.DataConfig) ([]EventCallData, error) {
+	l.Infow("len Events", "value", len(dataConfig.Events))
+	var events []common.Event
+	for k, event := range dataConfig.Events {
+		if k != strconv.FormatInt(int64(event.Id), 10) {
+			l.Errorw("wrong event key and id", "key", k, "id", event.Id)
+			return nil, fmt.Errorf("wrong event key and id %s %d", k, event.Id)
+		}
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].StartTime != events[j].StartTime {
+			return events[i].StartTime < events[j].StartTime
+		}
+		return events[i].Id < events[j].Id
+	})
+	if err := validateEventOverlaps(events); err != nil {
+		l.Errorw("overlapping events", "err", err)
+		return nil, err
+	}
+	result := make([]EventCallData, 0, 2*len(events))
+	for _, event := range events {
+		if event.StartTime >= event.EndTime {
+			return nil, fmt.Errorf("event %d: start time %d must be before end time %d", event.Id, event.StartTime, event.EndTime)
+		}
+		var (
+			startCallData, endCallData []byte
+			err                        error
+		)
+		switch event.Type {
+		case common.EventTypeExpAmp:
+			startCallData, endCallData, err = buildExpAmpEventData(event)
+		case common.EventTypeGacha:
+			startCallData, endCallData, err = buildGachaEventData(dataConfig, event)
+		default:
+			err = fmt.Errorf("event %d: unknown type %q", event.Id, event.Type)
+		}
+		if err != nil {
+			l.Errorw("cannot build event call data", "eventId", event.Id, "err", err)
+			return nil, err
+		}
+		result = append(result,
+			EventCallData{Time: event.StartTime, EventId: event.Id, Phase: EventPhaseStart, CallData: [][]byte{startCallData}})
+		if endCallData != nil {
+			result = append(result,
+				EventCallData{Time: event.EndTime, EventId: event.Id, Phase: EventPhaseEnd, CallData: [][]byte{endCallData}})
+		}
+	}
+	// at the same time an event ends before the next one on the same record starts
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Time != result[j].Time {
+			return result[i].Time < result[j].Time
+		}
+		return result[i].Phase == EventPhaseEnd && result[j].Phase == EventPhaseStart
+	})
+	return result, nil
+}
+
+// eventRecord is the record an event writes, events with the same record cannot overlap
+func eventRecord(event common.Event) string {
+	if event.Type == common.EventTypeGacha {
+		return fmt.Sprintf("%s:%d", event.Type, event.GachaId)
+	}
+	return string(event.Type)
+}
+
+// validateEventOverlaps expects events sorted by start time, an event may start when the previous one ends
+func validateEventOverlaps(events []common.Event) error {
+	last := make(map[string]common.Event)
+	for _, event := range events {
+		record := eventRecord(event)
+		if prev, ok := last[record]; ok && event.StartTime < prev.EndTime {
+			return fmt.Errorf("event %d (%s) overlaps event %d: starts at %d before %d",
+				event.Id, record, prev.Id, event.StartTime, prev.EndTime)
+		}
+		last[record] = event
+	}
+	return nil
+}
+
+// buildExpAmpEventData sets the amplifier until the event end, expireTime ends it on-chain
+// so there is no end calldata
+func buildExpAmpEventData(event common.Event) ([]byte, []byte, error) {
+	if event.ExpAmp == nil {
+		return nil, nil, fmt.Errorf("event %d: expAmp is required", event.Id)
+	}
+	expAmp := *event.ExpAmp
+	expAmp.ExpireTime = event.EndTime
+	startCallData, err := table.ExpAmpConfigCallData(expAmp)
+	if err != nil {
+		return nil, nil, err
+	}
+	return startCallData, nil, nil
+}
+
+// gachaClosedStartTime closes a banner, requestGacha reverts with Gacha_InactiveGacha before the start time
+const gachaClosedStartTime = math.MaxInt64
+
+// buildGachaEventData opens the banner at the event start and closes it at the end.
+// The record is kept since pending requests read the items when they are fulfilled.
+func buildGachaEventData(dataConfig common.DataConfig, event common.Event) ([]byte, []byte, error) {
+	gacha, ok := dataConfig.Gachas[strconv.Itoa(event.GachaId)]
+	if !ok {
+		return nil, nil, fmt.Errorf("event %d: gacha %d does not exist", event.Id, event.GachaId)
+	}
+	if err := validateGacha(dataConfig, gacha); err != nil {
+		return nil, nil, err
+	}
+	gacha.StartTime = event.StartTime
+	startCallData, err := table.GachaV5CallData(gacha)
+	if err != nil {
+		return nil, nil, err
+	}
+	gacha.StartTime = gachaClosedStartTime
+	endCallData, err := table.GachaV5CallData(gacha)
+	if err != nil {
+		return nil, nil, err
+	}
+	return startCallData, endCallData, nil
+}
@@ -0,0 +1,154 @@
+package calldata
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/ftk/post-deploy/pkg/mud"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBuildEventData(t *testing.T) {
+	dataConfig := common.DataConfig{
+		Items: map[string]common.Item{"7": {Id: 7}},
+		Gachas: map[string]common.Gacha{
+			"1": {Id: 1, TicketValue: 10, Items: []common.GachaItem{{ItemId: 7, Amount: 1, Percent: 10000}}},
+			"2": {Id: 2, TicketValue: 10, Items: []common.GachaItem{{ItemId: 7, Amount: 2, Percent: 10000}}},
+		},
+	}
+	expAmp := &common.ExpAmpConfig{PveExpAmp: 50, ExpireTime: 1}
+	gachaEvent := func(id, gachaId int, start, end int64) common.Event {
+		return common.Event{Id: id, Type: common.EventTypeGacha, GachaId: gachaId, StartTime: start, EndTime: end}
+	}
+	// phase describes one EventCallData as eventId/phase@time: decoded calldata
+	phase := func(e EventCallData) string {
+		require.Len(t, e.CallData, 1)
+		decoded, err := mud.DecodeCalldata(e.CallData[0])
+		require.NoError(t, err)
+		return fmt.Sprintf("%d/%s@%d: %s", e.EventId, e.Phase, e.Time, decoded)
+	}
+
+	tests := []struct {
+		name    string
+		events  []common.Event
+		want    []string
+		wantErr string
+	}{
+		{
+			name:   "exp amp expires on-chain without end calldata",
+			events: []common.Event{{Id: 1, Type: common.EventTypeExpAmp, StartTime: 100, EndTime: 200, ExpAmp: expAmp}},
+			want: []string{
+				"1/start@100: setRecord app:ExpAmpConfig () farmingPerkAmp=0 pveExpAmp=50 pvePerkAmp=0 expireTime=200",
+			},
+		},
+		{
+			name:   "gacha banner is closed and keeps its items",
+			events: []common.Event{gachaEvent(1, 1, 100, 200)},
+			want: []string{
+				"1/start@100: setRecord app:GachaV5 (id=1) startTime=100 ticketValue=10 ticketItemId=0 itemIds=[7] amounts=[1] percents=[10000]",
+				"1/end@200: setRecord app:GachaV5 (id=1) startTime=9223372036854775807 ticketValue=10 ticketItemId=0 itemIds=[7] amounts=[1] percents=[10000]",
+			},
+		},
+		{
+			name: "ends come before starts at the same time",
+			events: []common.Event{
+				gachaEvent(3, 1, 200, 300),
+				gachaEvent(2, 2, 150, 200),
+				gachaEvent(1, 1, 100, 200),
+			},
+			want: []string{
+				"1/start@100: setRecord app:GachaV5 (id=1) startTime=100 ticketValue=10 ticketItemId=0 itemIds=[7] amounts=[1] percents=[10000]",
+				"2/start@150: setRecord app:GachaV5 (id=2) startTime=150 ticketValue=10 ticketItemId=0 itemIds=[7] amounts=[2] percents=[10000]",
+				"1/end@200: setRecord app:GachaV5 (id=1) startTime=9223372036854775807 ticketValue=10 ticketItemId=0 itemIds=[7] amounts=[1] percents=[10000]",
+				"2/end@200: setRecord app:GachaV5 (id=2) startTime=9223372036854775807 ticketValue=10 ticketItemId=0 itemIds=[7] amounts=[2] percents=[10000]",
+				"3/start@200: setRecord app:GachaV5 (id=1) startTime=200 ticketValue=10 ticketItemId=0 itemIds=[7] amounts=[1] percents=[10000]",
+				"3/end@300: setRecord app:GachaV5 (id=1) startTime=9223372036854775807 ticketValue=10 ticketItemId=0 itemIds=[7] amounts=[1] percents=[10000]",
+			},
+		},
+		{
+			name:    "overlapping banner",
+			events:  []common.Event{gachaEvent(1, 1, 100, 200), gachaEvent(2, 1, 199, 300)},
+			wantErr: "event 2 (gacha:1) overlaps event 1",
+		},
+		{
+			name:    "start after end",
+			events:  []common.Event{gachaEvent(1, 1, 200, 200)},
+			wantErr: "event 1: start time 200 must be before end time 200",
+		},
+		{
+			name:    "missing gacha",
+			events:  []common.Event{gachaEvent(1, 3, 100, 200)},
+			wantErr: "event 1: gacha 3 does not exist",
+		},
+		{
+			name:    "missing exp amp",
+			events:  []common.Event{{Id: 1, Type: common.EventTypeExpAmp, StartTime: 100, EndTime: 200}},
+			wantErr: "event 1: expAmp is required",
+		},
+		{
+			name:    "unknown type",
+			events:  []common.Event{{Id: 1, Type: "drop", StartTime: 100, EndTime: 200}},
+			wantErr: `event 1: unknown type "drop"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataConfig.Events = make(map[string]common.Event)
+			for _, event := range tt.events {
+				dataConfig.Events[strconv.Itoa(event.Id)] = event
+			}
+			result, err := BuildEventData(zap.S(), dataConfig)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			got := make([]string, 0, len(result))
+			for _, e := range result {
+				got = append(got, phase(e))
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateEventOverlaps(t *testing.T) {
+	expAmp := func(id int, start, end int64) common.Event {
+		return common.Event{Id: id, Type: common.EventTypeExpAmp, StartTime: start, EndTime: end}
+	}
+	gacha := func(id, gachaId int, start, end int64) common.Event {
+		return common.Event{Id: id, Type: common.EventTypeGacha, GachaId: gachaId, StartTime: start, EndTime: end}
+	}
+	tests := []struct {
+		name    string
+		events  []common.Event
+		wantErr string
+	}{
+		{name: "back to back", events: []common.Event{expAmp(1, 100, 200), expAmp(2, 200, 300)}},
+		{name: "different banners", events: []common.Event{gacha(1, 1, 100, 200), gacha(2, 2, 150, 250)}},
+		{name: "banner and exp amp", events: []common.Event{gacha(1, 1, 100, 200), expAmp(2, 150, 250)}},
+		{
+			name:    "exp amp is a singleton",
+			events:  []common.Event{expAmp(1, 100, 200), expAmp(2, 150, 250)},
+			wantErr: "event 2 (expAmp) overlaps event 1: starts at 150 before 200",
+		},
+		{
+			name:    "same banner",
+			events:  []common.Event{gacha(1, 1, 100, 200), gacha(2, 2, 120, 130), gacha(3, 1, 150, 250)},
+			wantErr: "event 3 (gacha:1) overlaps event 1: starts at 150 before 200",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEventOverlaps(tt.events)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package calldata
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ftk/post-deploy/pkg/mud"
+)
+
+// ManifestLine describes one line of a post deploy file, Line is 1-based
+type ManifestLine struct {
+	Line         int      `json:"line"`
+	Section      string   `json:"section"`
+	Method       string   `json:"method"`
+	Table        string   `json:"table"`
+	Key          []string `json:"key"`
+	SourceFile   string   `json:"sourceFile"`
+	SourceId     string   `json:"sourceId,omitempty"` // key values of the record, the entry id in the source file
+	Hash         string   `json:"hash"`               // keccak256 of the calldata
+	EstimatedGas uint64   `json:"estimatedGas"`
+}
+
+// Manifest describes a post deploy file, RootHash is the keccak256 of the line hashes in order
+// so that a single edited, added or removed line changes it
+type Manifest struct {
+	File         string         `json:"file"`
+	RootHash     string         `json:"rootHash"`
+	EstimatedGas uint64         `json:"estimatedGas"`
+	Lines        []ManifestLine `json:"lines"`
+}
+
+// BuildManifest describes the calldata of the sections in the order they are written to file
+func BuildManifest(file string, sections []SectionCallData) (Manifest, error) {
+	manifest := Manifest{File: file}
+	var allCallData [][]byte
+	for _, section := range sections {
+		for _, callData := range section.CallData {
+			allCallData = append(allCallData, callData)
+			decoded, err := mud.DecodeCalldata(callData)
+			if err != nil {
+				return manifest, fmt.Errorf("line %d (%s): %w", len(allCallData), section.Name, err)
+			}
+			gas, err := mud.EstimateGas(callData)
+			if err != nil {
+				return manifest, fmt.Errorf("line %d (%s): %w", len(allCallData), section.Name, err)
+			}
+			line := ManifestLine{
+				Line:         len(allCallData),
+				Section:      section.Name,
+				Method:       decoded.Method,
+				Table:        decoded.Table,
+				Key:          make([]string, 0, len(decoded.Key)),
+				SourceFile:   section.Source,
+				Hash:         crypto.Keccak256Hash(callData).Hex(),
+				EstimatedGas: gas,
+			}
+			ids := make([]string, 0, len(decoded.Key))
+			for _, k := range decoded.Key {
+				line.Key = append(line.Key, k.String())
+				ids = append(ids, fmt.Sprint(k.Value))
+			}
+			line.SourceId = strings.Join(ids, ",")
+			manifest.Lines = append(manifest.Lines, line)
+			manifest.EstimatedGas += gas
+		}
+	}
+	manifest.RootHash = ManifestRootHash(allCallData).Hex()
+	return manifest, nil
+}
+
+// BuildBatchManifest describes a file of batchCall transactions packing the calldata of the sections,
+// each line names the sections and source files of its records. The estimated gas of a batch is
+// without the transaction base cost, like the one of a single store call.
+func BuildBatchManifest(file string, systemId mud.ResourceId, sections []SectionCallData, batches []mud.Batch) Manifest {
+	manifest := Manifest{File: file}
+	var recordSections []SectionCallData
+	for _, section := range sections {
+		for range section.CallData {
+			recordSections = append(recordSections, section)
+		}
+	}
+	allCallData := make([][]byte, 0, len(batches))
+	for i, batch := range batches {
+		allCallData = append(allCallData, batch.CallData)
+		var names, sources []string
+		for _, record := range batch.Records {
+			section := recordSections[record]
+			if len(names) == 0 || names[len(names)-1] != section.Name {
+				names = append(names, section.Name)
+			}
+			if !slices.Contains(sources, section.Source) {
+				sources = append(sources, section.Source)
+			}
+		}
+		line := ManifestLine{
+			Line:         i + 1,
+			Section:      strings.Join(names, ","),
+			Method:       "batchCall",
+			Table:        systemId.String(),
+			Key:          []string{},
+			SourceFile:   strings.Join(sources, ","),
+			Hash:         crypto.Keccak256Hash(batch.CallData).Hex(),
+			EstimatedGas: batch.EstimatedGas - mud.TX_BASE_GAS,
+		}
+		manifest.Lines = append(manifest.Lines, line)
+		manifest.EstimatedGas += line.EstimatedGas
+	}
+	manifest.RootHash = ManifestRootHash(allCallData).Hex()
+	return manifest
+}
+
+// ManifestRootHash is the root hash of a post deploy file with the given lines
+func ManifestRootHash(callData [][]byte) ethcommon.Hash {
+	lineHashes := make([][]byte, 0, len(callData))
+	for _, data := range callData {
+		lineHashes = append(lineHashes, crypto.Keccak256(data))
+	}
+	return crypto.Keccak256Hash(lineHashes...)
+}
+
+// Verify checks that the calldata read from the file is the one the manifest describes
+func (m Manifest) Verify(callData [][]byte) error {
+	if len(callData) != len(m.Lines) {
+		return fmt.Errorf("%s: %d lines, manifest has %d", m.File, len(callData), len(m.Lines))
+	}
+	for i, data := range callData {
+		if hash := crypto.Keccak256Hash(data).Hex(); hash != m.Lines[i].Hash {
+			return fmt.Errorf("%s: line %d (%s %s) hash %s, manifest has %s",
+				m.File, i+1, m.Lines[i].Table, strings.Join(m.Lines[i].Key, ","), hash, m.Lines[i].Hash)
+		}
+	}
+	if rootHash := ManifestRootHash(callData).Hex(); rootHash != m.RootHash {
+		return fmt.Errorf("%s: root hash %s, manifest has %s", m.File, rootHash, m.RootHash)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package calldata
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ftk/post-deploy/pkg/mud"
+	"github.com/ftk/post-deploy/pkg/table"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildManifest(t *testing.T) {
+	marketFee, err := table.MarketFeeCallData(1, 2, 5)
+	require.NoError(t, err)
+	crystalFee, err := table.CrystalFeeCallData(3, 10)
+	require.NoError(t, err)
+	sections := []SectionCallData{
+		{Name: "kingdomEconomy", Source: "map.json", CallData: [][]byte{marketFee}},
+		{Name: "crystal", Source: "kingdoms.json", CallData: [][]byte{crystalFee}},
+	}
+
+	manifest, err := BuildManifest("post_deploy.txt", sections)
+	require.NoError(t, err)
+	require.Equal(t, "post_deploy.txt", manifest.File)
+	want := []struct {
+		section, table, source, sourceId string
+		key                              []string
+		callData                         []byte
+	}{
+		{"kingdomEconomy", "MarketFee", "map.json", "1,2", []string{"kingdomAId=1", "kingdomBId=2"}, marketFee},
+		{"crystal", "CrystalFee", "kingdoms.json", "3", []string{"kingdomId=3"}, crystalFee},
+	}
+	require.Len(t, manifest.Lines, len(want))
+	var estimatedGas uint64
+	for i, w := range want {
+		line := manifest.Lines[i]
+		require.Equal(t, i+1, line.Line)
+		require.Equal(t, w.section, line.Section)
+		require.Equal(t, "setRecord", line.Method)
+		require.Equal(t, w.table, line.Table)
+		require.Equal(t, w.key, line.Key)
+		require.Equal(t, w.source, line.SourceFile)
+		require.Equal(t, w.sourceId, line.SourceId)
+		require.Equal(t, crypto.Keccak256Hash(w.callData).Hex(), line.Hash)
+		require.NotZero(t, line.EstimatedGas)
+		estimatedGas += line.EstimatedGas
+	}
+	require.Equal(t, estimatedGas, manifest.EstimatedGas)
+	require.Equal(t, crypto.Keccak256Hash(crypto.Keccak256(marketFee), crypto.Keccak256(crystalFee)).Hex(), manifest.RootHash)
+
+	require.NoError(t, manifest.Verify([][]byte{marketFee, crystalFee}))
+	edited := append([]byte{}, crystalFee...)
+	edited[len(edited)-1] ^= 1
+	require.ErrorContains(t, manifest.Verify([][]byte{marketFee, edited}), "post_deploy.txt: line 2 (CrystalFee kingdomId=3) hash")
+	require.ErrorContains(t, manifest.Verify([][]byte{marketFee}), "post_deploy.txt: 1 lines, manifest has 2")
+}
+
+func TestBuildBatchManifest(t *testing.T) {
+	section := func(name, source string, kingdomIds ...uint8) SectionCallData {
+		s := SectionCallData{Name: name, Source: source}
+		for _, id := range kingdomIds {
+			callData, err := table.MarketFeeCallData(id, id, 1)
+			require.NoError(t, err)
+			s.CallData = append(s.CallData, callData)
+		}
+		return s
+	}
+	sections := []SectionCallData{
+		section("kingdomEconomy", "map.json", 1, 2),
+		section("diplomacy", "map.json", 3),
+		section("gameConfig", "gameConfig.json", 4),
+	}
+	var callData [][]byte
+	for _, s := range sections {
+		callData = append(callData, s.CallData...)
+	}
+	systemId, err := mud.ParseResourceId("sy::PostDeploySystem")
+	require.NoError(t, err)
+	// the calldata limit fits 3 records in the first batch
+	batches, err := mud.BatchCalls(callData, mud.BatchConfig{SystemId: systemId, GasLimit: 15_000_000, MaxCalldataSize: 1_800})
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+
+	manifest := BuildBatchManifest("post_deploy_batch.txt", systemId, sections, batches)
+	require.Len(t, manifest.Lines, 2)
+	require.Equal(t, "kingdomEconomy,diplomacy", manifest.Lines[0].Section)
+	require.Equal(t, "map.json", manifest.Lines[0].SourceFile)
+	require.Equal(t, "gameConfig", manifest.Lines[1].Section)
+	require.Equal(t, "batchCall", manifest.Lines[1].Method)
+	require.Equal(t, "sy::PostDeploySystem", manifest.Lines[1].Table)
+	require.Equal(t, batches[0].EstimatedGas+batches[1].EstimatedGas-2*mud.TX_BASE_GAS, manifest.EstimatedGas)
+
+	batchCallData := [][]byte{batches[0].CallData, batches[1].CallData}
+	require.NoError(t, manifest.Verify(batchCallData))
+	require.Error(t, manifest.Verify(batchCallData[:1]))
+	require.Error(t, manifest.Verify([][]byte{batches[1].CallData, batches[0].CallData}))
+}
@@ -0,0 +1,212 @@
+package calldata
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/ftk/post-deploy/pkg/table"
+	"go.uber.org/zap"
+)
+
+const (
+	overlaySafeZone = "safe_zones"
+	overlayBlocked  = "blocked"
+	overlayNeutral  = "neutral"
+)
+
+// mapOverlay is an overlay of mapColor.json expanded into tiles, cityId is only set for safe zones
+type mapOverlay struct {
+	name   string
+	cityId int
+	tiles  []common.Location
+}
+
+// MapOverlayConflict is a blocked or neutral tile that also has monsters or farmable resources
+type MapOverlayConflict struct {
+	Overlay string
+	Tile    common.Location
+	With    string // monster or resource
+}
+
+func (c MapOverlayConflict) String() string {
+	return fmt.Sprintf("%s tile (%d, %d) has %s", c.Overlay, c.Tile.X, c.Tile.Y, c.With)
+}
+
+// BuildMapOverlayData sets RestrictLocV2 for city safe zones, Unmovable for blocked terrain and
+// NonOccupyTile for neutral areas. Blocked or neutral tiles with monsters or resources are reported, not rejected.
+func BuildMapOverlayData(
+	l *zap.SugaredLogger, dataConfig common.DataConfig, mapColor common.MapColor,
+	tileInfos []common.TileInfo, monsterLocations []common.MonsterLocation) ([][]byte, error) {
+	callData := make([][]byte, 0)
+	overlays, err := expandMapOverlays(dataConfig, mapColor)
+	if err != nil {
+		l.Errorw("invalid map overlays", "err", err)
+		return nil, err
+	}
+	for _, conflict := range findMapOverlayConflicts(overlays, tileInfos, monsterLocations) {
+		l.Warnw("map overlay conflict", "value", conflict.String())
+	}
+	for _, overlay := range overlays {
+		l.Infow("map overlay", "name", overlay.name, "cityId", overlay.cityId, "len tiles", len(overlay.tiles))
+		for _, tile := range overlay.tiles {
+			var (
+				tileCallData []byte
+				err          error
+			)
+			switch overlay.name {
+			case overlaySafeZone:
+				tileCallData, err = table.RestrictLocV2CallData(tile, overlay.cityId)
+			case overlayBlocked:
+				tileCallData, err = table.UnmovableCallData(tile)
+			case overlayNeutral:
+				tileCallData, err = table.NonOccupyTileCallData(tile)
+			}
+			if err != nil {
+				l.Errorw("cannot build map overlay call data", "overlay", overlay.name, "err", err)
+				return nil, err
+			}
+			callData = append(callData, tileCallData)
+		}
+	}
+	return callData, nil
+}
+
+// FindMapOverlayConflicts returns the blocked and neutral tiles that have monsters or farmable resources.
+// Safe zones only restrict pvp and new cities, their monsters and resources are still usable.
+func FindMapOverlayConflicts(
+	dataConfig common.DataConfig, mapColor common.MapColor,
+	tileInfos []common.TileInfo, monsterLocations []common.MonsterLocation) ([]MapOverlayConflict, error) {
+	overlays, err := expandMapOverlays(dataConfig, mapColor)
+	if err != nil {
+		return nil, err
+	}
+	return findMapOverlayConflicts(overlays, tileInfos, monsterLocations), nil
+}
+
+func findMapOverlayConflicts(
+	overlays []mapOverlay, tileInfos []common.TileInfo, monsterLocations []common.MonsterLocation) []MapOverlayConflict {
+	monsterTiles := make(map[common.Location]bool)
+	for _, ml := range monsterLocations {
+		for _, location := range ml.Locations {
+			monsterTiles[location] = true
+		}
+	}
+	resourceTiles := make(map[common.Location]bool)
+	for _, ti := range tileInfos {
+		if len(ti.ResourceItemIds) != 0 {
+			resourceTiles[common.Location{X: ti.X, Y: ti.Y}] = true
+		}
+	}
+	var conflicts []MapOverlayConflict
+	for _, overlay := range overlays {
+		if overlay.name == overlaySafeZone {
+			continue
+		}
+		for _, tile := range overlay.tiles {
+			if monsterTiles[tile] {
+				conflicts = append(conflicts, MapOverlayConflict{Overlay: overlay.name, Tile: tile, With: "monster"})
+			}
+			if resourceTiles[tile] {
+				conflicts = append(conflicts, MapOverlayConflict{Overlay: overlay.name, Tile: tile, With: "resource"})
+			}
+		}
+	}
+	return conflicts
+}
+
+// expandMapOverlays expands the overlay rectangles into sorted tiles inside the full map.
+// A tile can only be in the safe zone of one city and a city tile cannot be blocked.
+func expandMapOverlays(dataConfig common.DataConfig, mapColor common.MapColor) ([]mapOverlay, error) {
+	var overlays []mapOverlay
+	cityIds := make([]int, 0, len(mapColor.SafeZones))
+	for k := range mapColor.SafeZones {
+		cityId, err := strconv.Atoi(k)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid city id %q", overlaySafeZone, k)
+		}
+		cityIds = append(cityIds, cityId)
+	}
+	sort.Ints(cityIds)
+	safeTiles := make(map[common.Location]int)
+	for _, cityId := range cityIds {
+		city, ok := dataConfig.Cities[strconv.Itoa(cityId)]
+		if !ok {
+			return nil, fmt.Errorf("%s: city %d does not exist", overlaySafeZone, cityId)
+		}
+		tiles, err := overlayTiles(overlaySafeZone, mapColor.SafeZones[strconv.Itoa(cityId)], mapColor.FullMap)
+		if err != nil {
+			return nil, err
+		}
+		hasCityTile := false
+		for _, tile := range tiles {
+			if otherCityId, ok := safeTiles[tile]; ok {
+				return nil, fmt.Errorf("%s: tile (%d, %d) is in the zones of city %d and %d",
+					overlaySafeZone, tile.X, tile.Y, otherCityId, cityId)
+			}
+			safeTiles[tile] = cityId
+			hasCityTile = hasCityTile || (tile.X == city.X && tile.Y == city.Y)
+		}
+		if !hasCityTile {
+			return nil, fmt.Errorf("%s: city %d at (%d, %d) is outside its zone", overlaySafeZone, cityId, city.X, city.Y)
+		}
+		overlays = append(overlays, mapOverlay{name: overlaySafeZone, cityId: cityId, tiles: tiles})
+	}
+	blockedTiles, err := overlayTiles(overlayBlocked, mapColor.Blocked, mapColor.FullMap)
+	if err != nil {
+		return nil, err
+	}
+	for _, tile := range blockedTiles {
+		for _, city := range dataConfig.Cities {
+			if tile.X == city.X && tile.Y == city.Y {
+				return nil, fmt.Errorf("%s: tile (%d, %d) is city %d", overlayBlocked, tile.X, tile.Y, city.Id)
+			}
+		}
+	}
+	neutralTiles, err := overlayTiles(overlayNeutral, mapColor.Neutral, mapColor.FullMap)
+	if err != nil {
+		return nil, err
+	}
+	overlays = append(overlays,
+		mapOverlay{name: overlayBlocked, tiles: blockedTiles},
+		mapOverlay{name: overlayNeutral, tiles: neutralTiles},
+	)
+	return overlays, nil
+}
+
+// overlayTiles expands the rectangles of an overlay with GetAllTilesByLocation,
+// the rectangles must be inside the full map and at least 2 tiles wide and high
+func overlayTiles(name string, zones [][4]common.Location, fullMap [4]common.Location) ([]common.Location, error) {
+	var tiles []common.Location
+	for _, zone := range zones {
+		if err := validateOverlayZone(zone, fullMap); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		tiles = append(tiles, common.GetAllTilesByLocation(zone)...)
+	}
+	tiles = common.RemoveDupTile(tiles)
+	sort.Slice(tiles, func(i, j int) bool {
+		if tiles[i].X != tiles[j].X {
+			return tiles[i].X < tiles[j].X
+		}
+		return tiles[i].Y < tiles[j].Y
+	})
+	return tiles, nil
+}
+
+func validateOverlayZone(zone [4]common.Location, fullMap [4]common.Location) error {
+	if _, _, err := mapSize(zone); err != nil {
+		return err
+	}
+	topLeft, topRight, bottomRight, bottomLeft := zone[0], zone[1], zone[2], zone[3]
+	if topLeft.X >= topRight.X || bottomLeft.Y >= topLeft.Y ||
+		bottomRight.X != topRight.X || bottomRight.Y != bottomLeft.Y {
+		return fmt.Errorf("zone %v must be clockwise from the top left and at least 2x2", zone)
+	}
+	mapLeft, mapTop, mapRight, mapBottom := fullMap[0].X, fullMap[0].Y, fullMap[2].X, fullMap[2].Y
+	if topLeft.X < mapLeft || topLeft.Y > mapTop || bottomRight.X > mapRight || bottomRight.Y < mapBottom {
+		return fmt.Errorf("zone %v is outside the full map %v", zone, fullMap)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package calldata
+
+import (
+	"testing"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+// zone returns the rectangle [left, right]x[bottom, top] clockwise from the top left
+func zone(left, top, right, bottom int32) [4]common.Location {
+	return [4]common.Location{{X: left, Y: top}, {X: right, Y: top}, {X: right, Y: bottom}, {X: left, Y: bottom}}
+}
+
+func TestValidateOverlayZone(t *testing.T) {
+	fullMap := zone(-10, 10, 10, -10)
+	tests := []struct {
+		name    string
+		zone    [4]common.Location
+		wantErr string
+	}{
+		{name: "inside", zone: zone(-1, 1, 1, -1)},
+		{name: "full map", zone: fullMap},
+		{
+			name:    "counter-clockwise",
+			zone:    [4]common.Location{{X: -1, Y: 1}, {X: -1, Y: -1}, {X: 1, Y: -1}, {X: 1, Y: 1}},
+			wantErr: "must be clockwise from the top left",
+		},
+		{name: "one tile wide", zone: zone(0, 1, 0, -1), wantErr: "full map corner"},
+		{
+			name:    "not a rectangle",
+			zone:    [4]common.Location{{X: -1, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: -1}, {X: -1, Y: -1}},
+			wantErr: "is not on the rectangle",
+		},
+		{name: "outside the full map", zone: zone(5, 5, 11, 0), wantErr: "is outside the full map"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOverlayZone(tt.zone, fullMap)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestExpandMapOverlays(t *testing.T) {
+	dataConfig := common.DataConfig{
+		Cities: map[string]common.City{
+			"1": {Id: 1, X: -5, Y: 5},
+			"2": {Id: 2, X: 5, Y: -5},
+		},
+	}
+	fullMap := zone(-10, 10, 10, -10)
+	tests := []struct {
+		name      string
+		safeZones map[string][][4]common.Location
+		blocked   [][4]common.Location
+		want      map[string]int // overlay name => len tiles
+		wantErr   string
+	}{
+		{
+			name:      "safe zones and blocked terrain",
+			safeZones: map[string][][4]common.Location{"1": {zone(-6, 6, -4, 4)}, "2": {zone(4, -4, 6, -6)}},
+			blocked:   [][4]common.Location{zone(0, 1, 1, 0), zone(1, 1, 2, 0)},
+			want:      map[string]int{overlaySafeZone: 18, overlayBlocked: 6, overlayNeutral: 0},
+		},
+		{
+			name:      "safe zones overlap",
+			safeZones: map[string][][4]common.Location{"1": {zone(-6, 6, 0, 0)}, "2": {zone(0, 0, 6, -6)}},
+			wantErr:   "safe_zones: tile (0, 0) is in the zones of city 1 and 2",
+		},
+		{
+			name:      "city outside its zone",
+			safeZones: map[string][][4]common.Location{"1": {zone(0, 1, 1, 0)}},
+			wantErr:   "safe_zones: city 1 at (-5, 5) is outside its zone",
+		},
+		{
+			name:      "unknown city",
+			safeZones: map[string][][4]common.Location{"3": {zone(0, 1, 1, 0)}},
+			wantErr:   "safe_zones: city 3 does not exist",
+		},
+		{
+			name:      "invalid city id",
+			safeZones: map[string][][4]common.Location{"capital": {zone(0, 1, 1, 0)}},
+			wantErr:   `safe_zones: invalid city id "capital"`,
+		},
+		{
+			name:    "blocked city",
+			blocked: [][4]common.Location{zone(4, -4, 5, -5)},
+			wantErr: "blocked: tile (5, -5) is city 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapColor := common.MapColor{FullMap: fullMap, SafeZones: tt.safeZones, Blocked: tt.blocked}
+			overlays, err := expandMapOverlays(dataConfig, mapColor)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			got := make(map[string]int)
+			for _, overlay := range overlays {
+				got[overlay.name] += len(overlay.tiles)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFindMapOverlayConflicts(t *testing.T) {
+	overlays := []mapOverlay{
+		{name: overlaySafeZone, cityId: 1, tiles: []common.Location{{X: 0, Y: 0}, {X: 0, Y: 1}}},
+		{name: overlayBlocked, tiles: []common.Location{{X: 1, Y: 0}, {X: 1, Y: 1}}},
+		{name: overlayNeutral, tiles: []common.Location{{X: 2, Y: 0}, {X: 2, Y: 1}}},
+	}
+	tileInfos := []common.TileInfo{
+		{X: 0, Y: 0, ResourceItemIds: []int64{1}},
+		{X: 1, Y: 1, ResourceItemIds: []int64{1}},
+		{X: 2, Y: 0},
+		{X: 2, Y: 1, ResourceItemIds: []int64{1}},
+	}
+	monsterLocations := []common.MonsterLocation{
+		{MonsterId: 1, Locations: []common.Location{{X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 0}}},
+	}
+	got := make([]string, 0)
+	for _, conflict := range findMapOverlayConflicts(overlays, tileInfos, monsterLocations) {
+		got = append(got, conflict.String())
+	}
+	require.Equal(t, []string{
+		"blocked tile (1, 1) has monster",
+		"blocked tile (1, 1) has resource",
+		"neutral tile (2, 0) has monster",
+		"neutral tile (2, 1) has resource",
+	}, got)
+}
@@ -0,0 +1,207 @@
+package calldata
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	gentile "github.com/ftk/post-deploy/pkg/gen-tile"
+	"go.uber.org/zap"
+)
+
+// BuildContext is shared by the builders of a run, a builder may set the fields its dependents read
+type BuildContext struct {
+	DataConfig            common.DataConfig
+	MapConfig             []gentile.KingdomMap
+	MapColor              common.MapColor
+	CacheTileInfos        []common.TileInfo
+	CacheMonsterLocations []common.MonsterLocation
+	IsTest                bool
+
+	Cities           []common.City            // set by the city section
+	TileInfos        []common.TileInfo        // set by the tileInfo section
+	MonsterLocations []common.MonsterLocation // set by the tileInfo section
+}
+
+// Builder builds the calldata of one section of the post deploy data
+type Builder interface {
+	Name() string
+	Source() string // file the section is built from, for the deploy manifest
+	Deps() []string // sections to build before, for their calldata order or the context they set
+	Build(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error)
+}
+
+type BuildFunc func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error)
+
+type builder struct {
+	name   string
+	source string
+	deps   []string
+	build  BuildFunc
+}
+
+func NewBuilder(name, source string, deps []string, build BuildFunc) Builder {
+	return builder{name: name, source: source, deps: deps, build: build}
+}
+
+func (b builder) Name() string { return b.name }
+
+func (b builder) Source() string { return b.source }
+
+func (b builder) Deps() []string { return b.deps }
+
+func (b builder) Build(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+	return b.build(l, ctx)
+}
+
+var (
+	registry      = make(map[string]Builder)
+	registryOrder []string // sections without dependencies between them keep this order
+)
+
+// Register adds a section to the registry, it panics on a duplicated name like http.Handle
+func Register(b Builder) {
+	if b.Name() == "" {
+		panic("calldata: builder without name")
+	}
+	if _, ok := registry[b.Name()]; ok {
+		panic("calldata: builder registered twice: " + b.Name())
+	}
+	registry[b.Name()] = b
+	registryOrder = append(registryOrder, b.Name())
+}
+
+// Builders returns all the sections in build order
+func Builders() ([]Builder, error) {
+	return orderBuilders(registryOrder)
+}
+
+// SectionCallData is the calldata of one built section
+type SectionCallData struct {
+	Name     string
+	Source   string
+	CallData [][]byte
+}
+
+// BuildSections builds the selected sections, all of them if only is empty, except the skipped ones.
+// The dependencies of a selected section are built first so that it gets their context,
+// but their calldata is only returned if they are selected too.
+func BuildSections(l *zap.SugaredLogger, ctx *BuildContext, only, skip []string) ([][]byte, error) {
+	sections, err := BuildSectionData(l, ctx, only, skip)
+	if err != nil {
+		return nil, err
+	}
+	var callData [][]byte
+	for _, section := range sections {
+		callData = append(callData, section.CallData...)
+	}
+	return callData, nil
+}
+
+// BuildSectionData is BuildSections keeping the calldata of each section apart
+func BuildSectionData(l *zap.SugaredLogger, ctx *BuildContext, only, skip []string) ([]SectionCallData, error) {
+	selected, err := selectSections(only, skip)
+	if err != nil {
+		l.Errorw("invalid section selection", "err", err)
+		return nil, err
+	}
+	builders, err := orderBuilders(registryOrder)
+	if err != nil {
+		l.Errorw("cannot order builders", "err", err)
+		return nil, err
+	}
+	needed := make(map[string]bool)
+	var markNeeded func(name string)
+	markNeeded = func(name string) {
+		if needed[name] {
+			return
+		}
+		needed[name] = true
+		for _, dep := range registry[name].Deps() {
+			markNeeded(dep)
+		}
+	}
+	for name := range selected {
+		markNeeded(name)
+	}
+	var sections []SectionCallData
+	for _, b := range builders {
+		if !needed[b.Name()] {
+			continue
+		}
+		sectionCallData, err := b.Build(l.With("section", b.Name()), ctx)
+		if err != nil {
+			l.Errorw("cannot build section", "section", b.Name(), "err", err)
+			return nil, err
+		}
+		if !selected[b.Name()] {
+			l.Infow("built dependency", "section", b.Name())
+			continue
+		}
+		l.Infow("built section", "section", b.Name(), "len callData", len(sectionCallData))
+		sections = append(sections, SectionCallData{Name: b.Name(), Source: b.Source(), CallData: sectionCallData})
+	}
+	return sections, nil
+}
+
+func selectSections(only, skip []string) (map[string]bool, error) {
+	for _, name := range append(append([]string{}, only...), skip...) {
+		if _, ok := registry[name]; !ok {
+			return nil, fmt.Errorf("unknown section %q, available: %s", name, strings.Join(registryOrder, ", "))
+		}
+	}
+	selected := make(map[string]bool)
+	if len(only) == 0 {
+		for _, name := range registryOrder {
+			selected[name] = true
+		}
+	}
+	for _, name := range only {
+		selected[name] = true
+	}
+	for _, name := range skip {
+		delete(selected, name)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no section selected")
+	}
+	return selected, nil
+}
+
+// orderBuilders sorts the sections so that dependencies come first, otherwise in the given order
+func orderBuilders(names []string) ([]Builder, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	result := make([]Builder, 0, len(names))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		b, ok := registry[name]
+		if !ok {
+			return fmt.Errorf("unknown section %s required by %s", name, strings.Join(path, " -> "))
+		}
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle: %s -> %s", strings.Join(path, " -> "), name)
+		}
+		state[name] = visiting
+		for _, dep := range b.Deps() {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		result = append(result, b)
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,142 @@
+package calldata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// useTestRegistry replaces the registered sections for the test, each one builds its name as calldata
+// and appends it to built
+func useTestRegistry(t *testing.T, built *[]string, sections map[string][]string, order ...string) {
+	savedRegistry, savedOrder := registry, registryOrder
+	t.Cleanup(func() {
+		registry, registryOrder = savedRegistry, savedOrder
+	})
+	registry, registryOrder = make(map[string]Builder), nil
+	for _, name := range order {
+		Register(NewBuilder(name, name+".json", sections[name], func(l *zap.SugaredLogger, ctx *BuildContext) ([][]byte, error) {
+			*built = append(*built, name)
+			return [][]byte{[]byte(name)}, nil
+		}))
+	}
+}
+
+func TestOrderBuilders(t *testing.T) {
+	tests := []struct {
+		name     string
+		sections map[string][]string
+		order    []string
+		want     []string
+		wantErr  string
+	}{
+		{
+			name:  "registration order without dependencies",
+			order: []string{"c", "a", "b"},
+			want:  []string{"c", "a", "b"},
+		},
+		{
+			name:     "dependencies first",
+			sections: map[string][]string{"a": {"c"}, "c": {"b"}},
+			order:    []string{"a", "b", "c", "d"},
+			want:     []string{"b", "c", "a", "d"},
+		},
+		{
+			name:     "cycle",
+			sections: map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}},
+			order:    []string{"a", "b", "c"},
+			wantErr:  "dependency cycle: a -> b -> c -> a",
+		},
+		{
+			name:     "unknown dependency",
+			sections: map[string][]string{"b": {"x"}},
+			order:    []string{"a", "b"},
+			wantErr:  "unknown section x required by b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTestRegistry(t, new([]string), tt.sections, tt.order...)
+			builders, err := Builders()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			got := make([]string, 0, len(builders))
+			for _, b := range builders {
+				got = append(got, b.Name())
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBuildSectionData(t *testing.T) {
+	sections := map[string][]string{"a": {"c"}, "c": {"b"}}
+	order := []string{"a", "b", "c", "d"}
+	tests := []struct {
+		name      string
+		only      []string
+		skip      []string
+		wantBuilt []string
+		want      []string
+		wantErr   string
+	}{
+		{
+			name:      "all",
+			wantBuilt: []string{"b", "c", "a", "d"},
+			want:      []string{"b", "c", "a", "d"},
+		},
+		{
+			name:      "dependencies are built but not emitted",
+			only:      []string{"a"},
+			wantBuilt: []string{"b", "c", "a"},
+			want:      []string{"a"},
+		},
+		{
+			name:      "selected dependency is emitted",
+			only:      []string{"a", "b"},
+			wantBuilt: []string{"b", "c", "a"},
+			want:      []string{"b", "a"},
+		},
+		{
+			name:      "skipped dependency is still built",
+			skip:      []string{"c", "d"},
+			wantBuilt: []string{"b", "c", "a"},
+			want:      []string{"b", "a"},
+		},
+		{
+			name:    "unknown section",
+			only:    []string{"x"},
+			wantErr: `unknown section "x", available: a, b, c, d`,
+		},
+		{
+			name:    "nothing selected",
+			only:    []string{"d"},
+			skip:    []string{"d"},
+			wantErr: "no section selected",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var built []string
+			useTestRegistry(t, &built, sections, order...)
+			result, err := BuildSectionData(zap.S(), &BuildContext{}, tt.only, tt.skip)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantBuilt, built)
+			got := make([]string, 0, len(result))
+			for _, section := range result {
+				require.Equal(t, section.Name+".json", section.Source)
+				require.Equal(t, [][]byte{[]byte(section.Name)}, section.CallData)
+				got = append(got, section.Name)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package calldata
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ftk/post-deploy/pkg/common"
+)
+
+// ValidationProblem is an invalid entry of a data-config file, most often a broken reference
+type ValidationProblem struct {
+	File    string
+	Id      string
+	Message string
+}
+
+func (p ValidationProblem) String() string {
+	if p.Id == "" {
+		return fmt.Sprintf("%s: %s", p.File, p.Message)
+	}
+	return fmt.Sprintf("%s id %s: %s", p.File, p.Id, p.Message)
+}
+
+type validator struct {
+	dataConfig common.DataConfig
+	problems   []ValidationProblem
+}
+
+func (v *validator) add(file string, id any, format string, args ...any) {
+	v.problems = append(v.problems, ValidationProblem{File: file, Id: fmt.Sprint(id), Message: fmt.Sprintf(format, args...)})
+}
+
+// check adds the error of one of the validate functions shared with the builders
+func (v *validator) check(file string, id any, err error) {
+	if err != nil {
+		v.add(file, id, "%s", err)
+	}
+}
+
+func (v *validator) checkItem(file string, id any, field string, itemId int) {
+	if _, ok := v.dataConfig.Items[strconv.Itoa(itemId)]; !ok {
+		v.add(file, id, "%s: item %d does not exist", field, itemId)
+	}
+}
+
+func (v *validator) checkItems(file string, id any, field string, itemIds []int) {
+	for _, itemId := range itemIds {
+		v.checkItem(file, id, field, itemId)
+	}
+}
+
+func (v *validator) checkIngredients(file string, id any, field string, ingredients []common.Ingredient) {
+	for _, ingredient := range ingredients {
+		v.checkItem(file, id, field, ingredient.ItemId)
+		if ingredient.Amount <= 0 {
+			v.add(file, id, "%s: item %d amount must be positive", field, ingredient.ItemId)
+		}
+	}
+}
+
+func (v *validator) checkMonsterLocations(file string, monsterLocations []common.MonsterLocation) {
+	for _, ml := range monsterLocations {
+		if _, ok := v.dataConfig.Monsters[strconv.Itoa(ml.MonsterId)]; !ok {
+			v.add(file, ml.MonsterId, "monster does not exist")
+		}
+	}
+}
+
+// checkKey reports a map entry whose key is not its id, the builders reject them
+func (v *validator) checkKey(file, key string, id int64) {
+	if key != strconv.FormatInt(id, 10) {
+		v.add(file, key, "key does not match id %d", id)
+	}
+}
+
+// ValidateDataConfig walks every reference of the data config and returns all the problems found,
+// tileInfos and monsterLocations are the generated map data checked against the map overlays
+func ValidateDataConfig(
+	dataConfig common.DataConfig, mapColor common.MapColor,
+	tileInfos []common.TileInfo, monsterLocations []common.MonsterLocation) []ValidationProblem {
+	v := &validator{dataConfig: dataConfig}
+	v.validateItems()
+	v.validateMap()
+	v.validateQuests()
+	v.validateMonsters()
+
+	for _, k := range sortedKeys(dataConfig.ItemRecipes) {
+		recipe := dataConfig.ItemRecipes[k]
+		v.checkKey("itemRecipes.json", k, int64(recipe.ItemId))
+		v.checkItem("itemRecipes.json", k, "itemId", recipe.ItemId)
+		v.checkIngredients("itemRecipes.json", k, "ingredients", recipe.Ingredients)
+		if len(recipe.PerkItemTypes) != len(recipe.RequiredPerkLevels) {
+			v.add("itemRecipes.json", k, "%d perk types but %d perk levels", len(recipe.PerkItemTypes), len(recipe.RequiredPerkLevels))
+		}
+	}
+	for _, k := range sortedKeys(dataConfig.ItemExchanges) {
+		itemExchange := dataConfig.ItemExchanges[k]
+		v.checkKey("itemExchanges.json", k, int64(itemExchange.ItemId))
+		v.checkItem("itemExchanges.json", k, "itemId", itemExchange.ItemId)
+		v.checkIngredients("itemExchanges.json", k, "ingredients", itemExchange.Ingredients)
+	}
+	petItemType := dataConfig.ItemTypes["Pet"]
+	for _, k := range sortedKeys(dataConfig.PetComponentRates) {
+		petCpnRate := dataConfig.PetComponentRates[k]
+		v.checkKey("petComponentRates.json", k, int64(petCpnRate.PetItemId))
+		item, ok := dataConfig.Items[strconv.Itoa(petCpnRate.PetItemId)]
+		if !ok {
+			v.add("petComponentRates.json", k, "pet item %d does not exist", petCpnRate.PetItemId)
+		} else if item.Type != petItemType {
+			v.add("petComponentRates.json", k, "item %d is not a Pet", petCpnRate.PetItemId)
+		}
+	}
+	v.checkItems("welcomeConfig.json", "", "itemIds", dataConfig.WelcomeConfig.ItemIds)
+	for _, k := range sortedKeys(dataConfig.Skills) {
+		skill := dataConfig.Skills[k]
+		v.checkKey("skills.json", k, int64(skill.Id))
+		if len(skill.PerkItemTypes) != len(skill.RequiredPerkLevels) {
+			v.add("skills.json", k, "%d perk types but %d perk levels", len(skill.PerkItemTypes), len(skill.RequiredPerkLevels))
+		}
+	}
+	for _, k := range sortedKeys(dataConfig.Gachas) {
+		v.checkKey("gachas.json", k, int64(dataConfig.Gachas[k].Id))
+		v.check("gachas.json", k, validateGacha(dataConfig, dataConfig.Gachas[k]))
+	}
+	for _, k := range sortedKeys(dataConfig.SalePackages) {
+		v.checkKey("salePackages.json", k, int64(dataConfig.SalePackages[k].Id))
+		v.check("salePackages.json", k, validateSalePackage(dataConfig, dataConfig.SalePackages[k]))
+	}
+	for _, k := range sortedKeys(dataConfig.CityLevelRequires) {
+		v.checkKey("cityLevelRequires.json", k, int64(dataConfig.CityLevelRequires[k].Level))
+		v.check("cityLevelRequires.json", k, validateCityLevelRequire(dataConfig, dataConfig.CityLevelRequires[k]))
+	}
+	var events []common.Event
+	for _, k := range sortedKeys(dataConfig.Events) {
+		event := dataConfig.Events[k]
+		v.checkKey("events.json", k, int64(event.Id))
+		if event.Type == common.EventTypeGacha {
+			if _, ok := dataConfig.Gachas[strconv.Itoa(event.GachaId)]; !ok {
+				v.add("events.json", k, "gacha %d does not exist", event.GachaId)
+			}
+		}
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].StartTime < events[j].StartTime })
+	v.check("events.json", "", validateEventOverlaps(events))
+
+	conflicts, err := FindMapOverlayConflicts(dataConfig, mapColor, tileInfos, monsterLocations)
+	v.check("mapColor.json", "", err)
+	for _, conflict := range conflicts {
+		v.add("mapColor.json", "", "%s", conflict)
+	}
+	return v.problems
+}
+
+func (v *validator) validateItems() {
+	itemTypes := make(map[int]bool)
+	for _, itemType := range v.dataConfig.ItemTypes {
+		itemTypes[itemType] = true
+	}
+	categories := make(map[int]bool)
+	for _, category := range v.dataConfig.ItemCategoryTypes {
+		categories[category] = true
+	}
+	for _, k := range sortedKeys(v.dataConfig.Items) {
+		item := v.dataConfig.Items[k]
+		v.checkKey("items.json", k, int64(item.Id))
+		if !itemTypes[item.Type] {
+			v.add("items.json", k, "unknown item type %d", item.Type)
+		}
+		if !categories[item.Category] {
+			v.add("items.json", k, "unknown item category %d", item.Category)
+		}
+	}
+}
+
+// validateMap checks the kingdoms, cities and npcs of map.json
+func (v *validator) validateMap() {
+	const file = "map.json"
+	for _, k := range sortedKeys(v.dataConfig.Kingdoms) {
+		kingdom := v.dataConfig.Kingdoms[k]
+		v.checkKey(file, k, int64(kingdom.Id))
+		if _, ok := v.dataConfig.Cities[strconv.Itoa(kingdom.CapitalId)]; !ok {
+			v.add(file, k, "kingdom capital city %d does not exist", kingdom.CapitalId)
+		}
+	}
+	hasEconomy := false
+	for _, kingdom := range v.dataConfig.Kingdoms {
+		hasEconomy = hasEconomy || kingdom.Economy != nil
+	}
+	// like BuildKingdomEconomyData, the economy is optional but then every kingdom needs one
+	if hasEconomy {
+		v.check(file, "", validateMarketFeeMatrix(v.dataConfig))
+	}
+	v.check(file, "", validateDiplomacy(v.dataConfig))
+	for _, k := range sortedKeys(v.dataConfig.Cities) {
+		city := v.dataConfig.Cities[k]
+		v.checkKey(file, k, int64(city.Id))
+		if _, ok := v.dataConfig.Kingdoms[strconv.Itoa(int(city.KingdomId))]; !ok {
+			v.add(file, k, "city kingdom %d does not exist", city.KingdomId)
+		}
+		v.check(file, k, validateNpcShopStock(v.dataConfig, city))
+	}
+	for _, k := range sortedKeys(v.dataConfig.Npcs) {
+		npc := v.dataConfig.Npcs[k]
+		v.checkKey(file, k, npc.Id)
+		if _, ok := v.dataConfig.Cities[strconv.FormatInt(npc.CityId, 10)]; !ok {
+			v.add(file, k, "npc city %d does not exist", npc.CityId)
+		}
+	}
+	for _, ti := range v.dataConfig.TileInfos {
+		for _, itemId := range ti.ResourceItemIds {
+			v.checkItem("tileInfos.json", fmt.Sprintf("%d_%d", ti.X, ti.Y), "itemIds", int(itemId))
+		}
+	}
+}
+
+func (v *validator) validateQuests() {
+	const file = "quests.json"
+	for _, k := range sortedKeys(v.dataConfig.Quests) {
+		quest := v.dataConfig.Quests[k]
+		v.checkKey(file, k, quest.Id)
+		if _, ok := v.dataConfig.Npcs[strconv.FormatInt(quest.FromNpcId, 10)]; !ok {
+			v.add(file, k, "fromNpcId: npc %d does not exist", quest.FromNpcId)
+		}
+		// 0 is the same npc as fromNpcId
+		if _, ok := v.dataConfig.Npcs[strconv.FormatInt(quest.ToNpcId, 10)]; !ok && quest.ToNpcId != 0 {
+			v.add(file, k, "toNpcId: npc %d does not exist", quest.ToNpcId)
+		}
+		for _, achievementId := range quest.RequiredAchievementIds {
+			if _, ok := v.dataConfig.Achievements[strconv.FormatInt(achievementId, 10)]; !ok {
+				v.add(file, k, "requiredAchievementIds: achievement %d does not exist", achievementId)
+			}
+		}
+		for _, questId := range quest.RequiredDoneQuestIds {
+			if _, ok := v.dataConfig.Quests[strconv.FormatInt(questId, 10)]; !ok {
+				v.add(file, k, "requiredDoneQuestIds: quest %d does not exist", questId)
+			}
+			if questId == quest.Id {
+				v.add(file, k, "requiredDoneQuestIds: quest requires itself")
+			}
+		}
+		if _, ok := v.dataConfig.Achievements[strconv.FormatInt(quest.AchievementId, 10)]; !ok && quest.AchievementId != 0 {
+			v.add(file, k, "achievementId: achievement %d does not exist", quest.AchievementId)
+		}
+		for _, itemId := range quest.RewardItemIds {
+			v.checkItem(file, k, "rewardItemIds", int(itemId))
+		}
+		if len(quest.RewardItemIds) != len(quest.RewardItemAmounts) {
+			v.add(file, k, "%d reward items but %d reward amounts", len(quest.RewardItemIds), len(quest.RewardItemAmounts))
+		}
+		for _, detail := range quest.ContributeDetails {
+			v.checkItem(file, k, "contributeDetails", int(detail.ItemId))
+		}
+	}
+}
+
+func (v *validator) validateMonsters() {
+	const file = "monsters.json"
+	for _, k := range sortedKeys(v.dataConfig.Monsters) {
+		monster := v.dataConfig.Monsters[k]
+		v.checkKey(file, k, int64(monster.Id))
+		for _, skillId := range monster.SkillIds {
+			if _, ok := v.dataConfig.Skills[strconv.Itoa(skillId)]; !ok {
+				v.add(file, k, "skillIds: skill %d does not exist", skillId)
+			}
+		}
+		v.checkItems(file, k, "itemIds", monster.ItemIds)
+		if len(monster.ItemIds) != len(monster.ItemAmounts) {
+			v.add(file, k, "%d items but %d item amounts", len(monster.ItemIds), len(monster.ItemAmounts))
+		}
+	}
+	v.checkMonsterLocations("monsterLocationsCache.json", v.dataConfig.MonsterLocationsCache)
+	v.checkMonsterLocations("monsterLocationsOverride.json", v.dataConfig.MonsterLocationsOverride)
+	v.checkMonsterLocations("monsterLocationsBoss.json", v.dataConfig.MonsterLocationsBoss)
+}
+
+// sortedKeys returns the keys of a data config map in id order, non numeric keys last
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.ParseInt(keys[i], 10, 64)
+		b, errB := strconv.ParseInt(keys[j], 10, 64)
+		if errA != nil || errB != nil {
+			if (errA == nil) != (errB == nil) {
+				return errA == nil
+			}
+			return keys[i] < keys[j]
+		}
+		return a < b
+	})
+	return keys
+}
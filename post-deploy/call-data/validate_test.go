@@ -0,0 +1,116 @@
+package calldata
+
+import (
+	"testing"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+// validDataConfig has one entry of each kind referencing the others, item 9 and every other id 9 do not exist
+func validDataConfig() common.DataConfig {
+	return common.DataConfig{
+		ItemTypes:         map[common.ItemType]int{"Weapon": 1, "Pet": 2},
+		ItemCategoryTypes: map[common.ItemCategoryType]int{"Other": 0},
+		Items: map[string]common.Item{
+			"1": {Id: 1, Type: 1},
+			"2": {Id: 2, Type: 1},
+			"3": {Id: 3, Type: 2},
+		},
+		Skills:   map[string]common.Skill{"1": {Id: 1}},
+		Monsters: map[string]common.Monster{"1": {Id: 1, SkillIds: []int{1}, ItemIds: []int{1}, ItemAmounts: []int{1}}},
+		Kingdoms: map[string]common.Kingdom{"1": {Id: 1, CapitalId: 1}},
+		Cities:   map[string]common.City{"1": {Id: 1, KingdomId: 1}},
+		Npcs:     map[string]common.Npc{"1": {Id: 1, CityId: 1}},
+		Quests: map[string]common.QuestV4{
+			"1": {Id: 1, FromNpcId: 1},
+			"2": {Id: 2, FromNpcId: 1, RequiredDoneQuestIds: []int64{1}, RewardItemIds: []int64{1}, RewardItemAmounts: []uint32{1}},
+		},
+		ItemRecipes:       map[string]common.ItemRecipe{"2": {ItemId: 2, Ingredients: []common.Ingredient{{ItemId: 1, Amount: 1}}}},
+		ItemExchanges:     map[string]common.ItemExchange{"2": {ItemId: 2, Ingredients: []common.Ingredient{{ItemId: 1, Amount: 1}}}},
+		PetComponentRates: map[string]common.PetCpnRate{"3": {PetItemId: 3}},
+		WelcomeConfig:     common.WelcomeConfig{ItemIds: []int{1}},
+	}
+}
+
+func TestValidateDataConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(d *common.DataConfig)
+		want   []ValidationProblem
+	}{
+		{
+			name:   "valid",
+			mutate: func(d *common.DataConfig) {},
+		},
+		{
+			name: "monster item",
+			mutate: func(d *common.DataConfig) {
+				d.Monsters["1"] = common.Monster{Id: 1, SkillIds: []int{1}, ItemIds: []int{9}, ItemAmounts: []int{1}}
+			},
+			want: []ValidationProblem{{File: "monsters.json", Id: "1", Message: "itemIds: item 9 does not exist"}},
+		},
+		{
+			name: "monster skill",
+			mutate: func(d *common.DataConfig) {
+				d.Monsters["1"] = common.Monster{Id: 1, SkillIds: []int{9}, ItemIds: []int{1}, ItemAmounts: []int{1}}
+			},
+			want: []ValidationProblem{{File: "monsters.json", Id: "1", Message: "skillIds: skill 9 does not exist"}},
+		},
+		{
+			name: "recipe ingredient",
+			mutate: func(d *common.DataConfig) {
+				d.ItemRecipes["2"] = common.ItemRecipe{ItemId: 2, Ingredients: []common.Ingredient{{ItemId: 9, Amount: 1}}}
+			},
+			want: []ValidationProblem{{File: "itemRecipes.json", Id: "2", Message: "ingredients: item 9 does not exist"}},
+		},
+		{
+			name:   "welcome config item",
+			mutate: func(d *common.DataConfig) { d.WelcomeConfig.ItemIds = []int{1, 9} },
+			want:   []ValidationProblem{{File: "welcomeConfig.json", Id: "", Message: "itemIds: item 9 does not exist"}},
+		},
+		{
+			name: "quest ids",
+			mutate: func(d *common.DataConfig) {
+				d.Quests["2"] = common.QuestV4{
+					Id: 2, FromNpcId: 9, RequiredAchievementIds: []int64{9}, RequiredDoneQuestIds: []int64{9},
+					RewardItemIds: []int64{9}, RewardItemAmounts: []uint32{1},
+				}
+			},
+			want: []ValidationProblem{
+				{File: "quests.json", Id: "2", Message: "fromNpcId: npc 9 does not exist"},
+				{File: "quests.json", Id: "2", Message: "requiredAchievementIds: achievement 9 does not exist"},
+				{File: "quests.json", Id: "2", Message: "requiredDoneQuestIds: quest 9 does not exist"},
+				{File: "quests.json", Id: "2", Message: "rewardItemIds: item 9 does not exist"},
+			},
+		},
+		{
+			name: "item exchange",
+			mutate: func(d *common.DataConfig) {
+				d.ItemExchanges["9"] = common.ItemExchange{ItemId: 9, Ingredients: []common.Ingredient{{ItemId: 9, Amount: 1}}}
+			},
+			want: []ValidationProblem{
+				{File: "itemExchanges.json", Id: "9", Message: "itemId: item 9 does not exist"},
+				{File: "itemExchanges.json", Id: "9", Message: "ingredients: item 9 does not exist"},
+			},
+		},
+		{
+			name: "pet component rates",
+			mutate: func(d *common.DataConfig) {
+				d.PetComponentRates["2"] = common.PetCpnRate{PetItemId: 2}
+				d.PetComponentRates["9"] = common.PetCpnRate{PetItemId: 9}
+			},
+			want: []ValidationProblem{
+				{File: "petComponentRates.json", Id: "2", Message: "item 2 is not a Pet"},
+				{File: "petComponentRates.json", Id: "9", Message: "pet item 9 does not exist"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataConfig := validDataConfig()
+			tt.mutate(&dataConfig)
+			require.Equal(t, tt.want, ValidateDataConfig(dataConfig, common.MapColor{}, nil, nil))
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	calldata "github.com/ftk/post-deploy/call-data"
+	"github.com/ftk/post-deploy/pkg/mud"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+const (
+	batchFlag              = "batch"
+	batchOutFlag           = "batch-out"
+	batchManifestFlag      = "batch-manifest"
+	batchSystemFlag        = "batch-system"
+	batchGasLimitFlag      = "batch-gas"
+	batchCalldataLimitFlag = "batch-calldata"
+)
+
+func batchFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:  batchFlag,
+			Usage: "also write the data packed into World batchCall transactions",
+		},
+		cli.StringFlag{
+			Name:  batchOutFlag,
+			Usage: "path to output batched data file",
+			Value: "../../post_deploy_batch.txt",
+		},
+		cli.StringFlag{
+			Name:  batchManifestFlag,
+			Usage: "path to output the records in each batch, send verifies <batch-out>_manifest.json",
+			Value: "../../post_deploy_batch.json",
+		},
+		cli.StringFlag{
+			Name:  batchSystemFlag,
+			Usage: "root system receiving the batched store calls, as sy:namespace:name or bytes32 hex",
+			Value: "sy::PostDeploySystem",
+		},
+		cli.Uint64Flag{
+			Name:  batchGasLimitFlag,
+			Usage: "max estimated gas of a batch transaction",
+			Value: 15_000_000,
+		},
+		cli.IntFlag{
+			Name:  batchCalldataLimitFlag,
+			Usage: "max calldata bytes of a batch transaction",
+			Value: 120_000,
+		},
+	}
+}
+
+// batchManifest describes one batch transaction and the records it contains
+type batchManifest struct {
+	Batch        int             `json:"batch"`
+	EstimatedGas uint64          `json:"estimatedGas"`
+	CalldataSize int             `json:"calldataSize"`
+	Records      []batchedRecord `json:"records"`
+}
+
+// batchedRecord is one store call of a batch, Line is the 1-based line in the unbatched file
+type batchedRecord struct {
+	Line   int    `json:"line"`
+	Method string `json:"method"`
+	Table  string `json:"table"`
+	Key    string `json:"key"`
+}
+
+// parseResourceIdFlag accepts either type:namespace:name or a 0x prefixed bytes32
+func parseResourceIdFlag(value string) (mud.ResourceId, error) {
+	if strings.HasPrefix(value, "0x") {
+		raw, err := hexutil.Decode(value)
+		if err != nil {
+			return mud.ResourceId{}, err
+		}
+		return mud.ResourceIdFromBytes(raw)
+	}
+	return mud.ParseResourceId(value)
+}
+
+// writeBatchData packs the calldata of the sections into batchCall transactions and writes them with
+// the records of each batch and the manifest send verifies
+func writeBatchData(c *cli.Context, sections []calldata.SectionCallData) error {
+	l := zap.S().With("func", "writeBatchData")
+	var rawCallDatas [][]byte
+	for _, section := range sections {
+		rawCallDatas = append(rawCallDatas, section.CallData...)
+	}
+	systemId, err := parseResourceIdFlag(c.String(batchSystemFlag))
+	if err != nil {
+		l.Errorw("invalid batch system", "err", err)
+		return err
+	}
+	config := mud.BatchConfig{
+		SystemId:        systemId,
+		GasLimit:        c.Uint64(batchGasLimitFlag),
+		MaxCalldataSize: c.Int(batchCalldataLimitFlag),
+	}
+	batches, err := mud.BatchCalls(rawCallDatas, config)
+	if err != nil {
+		l.Errorw("cannot batch call data", "err", err)
+		return err
+	}
+	manifest := make([]batchManifest, 0, len(batches))
+	batchCallDatas := make([][]byte, 0, len(batches))
+	for i, batch := range batches {
+		m := batchManifest{
+			Batch:        i + 1,
+			EstimatedGas: batch.EstimatedGas,
+			CalldataSize: len(batch.CallData),
+		}
+		for _, index := range batch.Records {
+			decoded, err := mud.DecodeCalldata(rawCallDatas[index])
+			if err != nil {
+				l.Errorw("cannot decode call data", "line", index+1, "err", err)
+				return err
+			}
+			keys := make([]string, 0, len(decoded.Key))
+			for _, k := range decoded.Key {
+				keys = append(keys, k.String())
+			}
+			m.Records = append(m.Records, batchedRecord{
+				Line:   index + 1,
+				Method: decoded.Method,
+				Table:  decoded.Table,
+				Key:    strings.Join(keys, ","),
+			})
+		}
+		manifest = append(manifest, m)
+		batchCallDatas = append(batchCallDatas, batch.CallData)
+	}
+	l.Infow("batched call data", "len records", len(rawCallDatas), "len batches", len(batches))
+	if err := writeLineToFile(c.String(batchOutFlag), batchCallDatas); err != nil {
+		l.Errorw("cannot write batch data to file", "err", err)
+		return err
+	}
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.String(batchManifestFlag), manifestBytes, 0644); err != nil {
+		return err
+	}
+	filePath := c.String(batchOutFlag)
+	sendManifest := calldata.BuildBatchManifest(filepath.Base(filePath), systemId, sections, batches)
+	sendManifestBytes, err := json.MarshalIndent(sendManifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	l.Infow("manifest", "file", manifestPath(filePath), "rootHash", sendManifest.RootHash,
+		"estimatedGas", sendManifest.EstimatedGas)
+	return os.WriteFile(manifestPath(filePath), sendManifestBytes, 0644)
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	calldata "github.com/ftk/post-deploy/call-data"
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+const (
+	localFlag        = "local"
+	listSectionsFlag = "list-sections"
+)
+
+func buildCommand() cli.Command {
+	return cli.Command{
+		Name:  "build",
+		Usage: "build the post-deploy calldata file and its manifest",
+		Flags: append([]cli.Flag{
+			cli.BoolFlag{
+				Name:  testFlag,
+				Usage: "to build test data into " + pathToTestFile + ", --out is ignored",
+			},
+			cli.StringFlag{
+				Name:  outFlag,
+				Usage: "path to output data file",
+				Value: "../../post_deploy.txt",
+			},
+			cli.BoolFlag{
+				Name:  localFlag,
+				Usage: "build a small data for local, the rest is written by build-reserve",
+			},
+			cli.Int64Flag{
+				Name:  dataPercentFlag,
+				Usage: "percent of big data to be build with --local",
+				Value: 1,
+			},
+			cli.StringFlag{
+				Name:  onlyFlag,
+				Usage: "comma separated sections to build, all by default",
+			},
+			cli.StringFlag{
+				Name:  skipFlag,
+				Usage: "comma separated sections not to build",
+			},
+			cli.BoolFlag{
+				Name:  listSectionsFlag,
+				Usage: "list the sections and their dependencies instead of building",
+			},
+		}, batchFlags()...),
+		Action: runBuild,
+	}
+}
+
+func buildReserveCommand() cli.Command {
+	return cli.Command{
+		Name:  "build-reserve",
+		Usage: "build the tile infos and monster locations left out by build --local, from the cached map data",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  outFlag,
+				Usage: "path to output reserve data file",
+				Value: "../../post_deploy_reserve.txt",
+			},
+			cli.Int64Flag{
+				Name:  dataPercentFlag,
+				Usage: "percent of big data built by build --local, the rest is reserved",
+				Value: 1,
+			},
+		},
+		Action: runBuildReserve,
+	}
+}
+
+func runBuild(c *cli.Context) error {
+	var (
+		l = zap.S().With("func", "runBuild")
+	)
+	if c.Bool(listSectionsFlag) {
+		return listSections()
+	}
+	isTest := c.Bool(testFlag)
+	dataConfig, err := getDataConfig(isTest)
+	if err != nil {
+		l.Errorw("cannot get data config", "err", err)
+		return err
+	}
+	// initMapEnums init map enum value in config
+	common.InitMapEnums(dataConfig)
+
+	// mapConfig for the distribution
+	mapConfig, err := getMapConfig()
+	if err != nil {
+		l.Errorw("cannot get data config", "err", err)
+		return err
+	}
+	mapColor, err := getMapColor()
+	if err != nil {
+		l.Errorw("cannot get map color", "err", err)
+		return err
+	}
+
+	// load data to process (from cache)
+	var (
+		cacheTileInfos        []common.TileInfo
+		cacheMonsterLocations []common.MonsterLocation
+	)
+	if !isTest {
+		if !c.Bool(localFlag) {
+			cacheTileInfos, cacheMonsterLocations, err = getAllCachedDeployData(mapConfig)
+			if err != nil {
+				l.Errorw("cannot get full cached data", "err", err)
+				return err
+			}
+			l.Infow("full data", "len tile infos", len(cacheTileInfos), "len monster location", len(cacheMonsterLocations))
+		} else {
+			cacheTileInfos, cacheMonsterLocations, err = splitDeployData(
+				mapConfig, dataConfig, "", false, c.Int64(dataPercentFlag))
+			if err != nil {
+				l.Errorw("cannot get process data", "err", err)
+				return err
+			}
+		}
+	}
+
+	// buildCallData build post_deploy data
+	sections, err := buildCallData(dataConfig, mapConfig, mapColor, cacheMonsterLocations,
+		cacheTileInfos, isTest, splitSections(c.String(onlyFlag)), splitSections(c.String(skipFlag)))
+	if err != nil {
+		return err
+	}
+	// write to file
+	filePath := c.String(outFlag)
+	if isTest {
+		filePath = pathToTestFile
+	}
+	if err := writeCallDataFile(filePath, sections); err != nil {
+		l.Errorw("cannot write call data to file", "err", err)
+		return err
+	}
+	if c.Bool(batchFlag) {
+		return writeBatchData(c, sections)
+	}
+	return nil
+}
+
+func runBuildReserve(c *cli.Context) error {
+	l := zap.S().With("func", "runBuildReserve")
+	dataConfig, err := getDataConfig(false)
+	if err != nil {
+		l.Errorw("cannot get data config", "err", err)
+		return err
+	}
+	common.InitMapEnums(dataConfig)
+	mapConfig, err := getMapConfig()
+	if err != nil {
+		l.Errorw("cannot get map config", "err", err)
+		return err
+	}
+	tileInfos, _, err := splitDeployData(mapConfig, dataConfig, c.String(outFlag), true, c.Int64(dataPercentFlag))
+	if err != nil {
+		l.Errorw("cannot build reserve data", "err", err)
+		return err
+	}
+	if tileInfos == nil {
+		return errors.New("no cached map data, run gen-map first")
+	}
+	return nil
+}
+
+func listSections() error {
+	builders, err := calldata.Builders()
+	if err != nil {
+		return err
+	}
+	for _, b := range builders {
+		if len(b.Deps()) == 0 {
+			fmt.Println(b.Name())
+			continue
+		}
+		fmt.Printf("%s (after %s)\n", b.Name(), strings.Join(b.Deps(), ", "))
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	calldata "github.com/ftk/post-deploy/call-data"
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/ftk/post-deploy/pkg/mud"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+const (
+	inFlag       = "in"
+	jsonFlag     = "json"
+	manifestFlag = "manifest"
+)
+
+// decodedLine is one line of a post-deploy file in JSON lines output
+type decodedLine struct {
+	Line int `json:"line"`
+	mud.DecodedCall
+	Error string `json:"error,omitempty"`
+}
+
+func decodeCommand() cli.Command {
+	return cli.Command{
+		Name:  "decode",
+		Usage: "explain a post-deploy calldata file line by line",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  inFlag,
+				Usage: "path to the calldata file to decode",
+				Value: "../../post_deploy.txt",
+			},
+			cli.BoolFlag{
+				Name:  jsonFlag,
+				Usage: "print JSON lines instead of human-readable text",
+			},
+			cli.StringFlag{
+				Name:  manifestFlag,
+				Usage: "path to the manifest of the file, to check that the file was not edited",
+			},
+		},
+		Action: runDecode,
+	}
+}
+
+func runDecode(c *cli.Context) error {
+	l := zap.S().With("func", "runDecode")
+	f, err := os.Open(c.String(inFlag))
+	if err != nil {
+		l.Errorw("cannot open calldata file", "err", err)
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	encoder := json.NewEncoder(w)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 16*1024*1024)
+	numErrors := 0
+	var callDatas [][]byte
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		raw := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "0x")
+		if raw == "" {
+			continue
+		}
+		line := decodedLine{Line: lineNumber}
+		callData, err := hex.DecodeString(raw)
+		if err == nil {
+			callDatas = append(callDatas, callData)
+			line.DecodedCall, err = mud.DecodeCalldata(callData)
+		}
+		if err != nil {
+			numErrors++
+			line.Error = err.Error()
+		}
+		if c.Bool(jsonFlag) {
+			if err := encoder.Encode(line); err != nil {
+				return err
+			}
+			continue
+		}
+		if line.Error != "" {
+			fmt.Fprintf(w, "%d: ERROR %s\n", line.Line, line.Error)
+			continue
+		}
+		fmt.Fprintf(w, "%d: %s\n", line.Line, line.DecodedCall)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if numErrors > 0 {
+		return fmt.Errorf("%d lines cannot be decoded", numErrors)
+	}
+	if c.String(manifestFlag) != "" {
+		var manifest calldata.Manifest
+		if err := common.ParseFile(c.String(manifestFlag), &manifest); err != nil {
+			l.Errorw("cannot parse manifest", "err", err)
+			return err
+		}
+		if err := manifest.Verify(callDatas); err != nil {
+			l.Errorw("file does not match manifest", "err", err)
+			return err
+		}
+		l.Infow("file matches manifest", "rootHash", manifest.RootHash)
+	}
+	return nil
+}
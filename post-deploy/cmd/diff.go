@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+
+	calldata "github.com/ftk/post-deploy/call-data"
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/ftk/post-deploy/pkg/mud"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+const (
+	baseFlag     = "base"
+	deltaOutFlag = "delta-out"
+)
+
+func diffCommand() cli.Command {
+	return cli.Command{
+		Name:  "diff",
+		Usage: "build the calldata patching a world deployed from a git revision to the working tree config",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  testFlag,
+				Usage: "to use the test data config",
+			},
+			cli.StringFlag{
+				Name:  baseFlag,
+				Usage: "git revision of the deployed data config",
+				Value: "HEAD",
+			},
+			cli.StringFlag{
+				Name:  deltaOutFlag,
+				Usage: "path to output delta data file",
+				Value: "../../post_deploy_delta.txt",
+			},
+			cli.StringFlag{
+				Name:  onlyFlag,
+				Usage: "comma separated sections to diff, all by default",
+			},
+			cli.StringFlag{
+				Name:  skipFlag,
+				Usage: "comma separated sections not to diff",
+			},
+		},
+		Action: runDiff,
+	}
+}
+
+func runDiff(c *cli.Context) error {
+	l := zap.S().With("func", "runDiff")
+	isTest := c.Bool(testFlag)
+	baseConfig, err := getDataConfigAtRevision(isTest, c.String(baseFlag))
+	if err != nil {
+		l.Errorw("cannot get base data config", "err", err)
+		return err
+	}
+	dataConfig, err := getDataConfig(isTest)
+	if err != nil {
+		l.Errorw("cannot get data config", "err", err)
+		return err
+	}
+	common.InitMapEnums(dataConfig)
+	mapConfig, err := getMapConfig()
+	if err != nil {
+		l.Errorw("cannot get map config", "err", err)
+		return err
+	}
+	mapColor, err := getMapColor()
+	if err != nil {
+		l.Errorw("cannot get map color", "err", err)
+		return err
+	}
+	var (
+		cacheTileInfos        []common.TileInfo
+		cacheMonsterLocations []common.MonsterLocation
+	)
+	if !isTest {
+		cacheTileInfos, cacheMonsterLocations, err = getAllCachedDeployData(mapConfig)
+		if err != nil {
+			l.Errorw("cannot get full cached data", "err", err)
+			return err
+		}
+	}
+	newContext := func(dataConfig common.DataConfig) *calldata.BuildContext {
+		return &calldata.BuildContext{
+			DataConfig:            dataConfig,
+			MapConfig:             mapConfig,
+			MapColor:              mapColor,
+			CacheTileInfos:        cacheTileInfos,
+			CacheMonsterLocations: cacheMonsterLocations,
+			IsTest:                isTest,
+		}
+	}
+	changes, err := calldata.BuildDeltaData(l, newContext(baseConfig), newContext(dataConfig),
+		splitSections(c.String(onlyFlag)), splitSections(c.String(skipFlag)))
+	if err != nil {
+		return err
+	}
+
+	var (
+		deltaCallData [][]byte
+		numChanges    = make(map[mud.ChangeKind]int)
+	)
+	for _, change := range changes {
+		fmt.Println(change)
+		deltaCallData = append(deltaCallData, change.CallData...)
+		numChanges[change.Kind]++
+	}
+	l.Infow("delta",
+		"added", numChanges[mud.ChangeAdded],
+		"changed", numChanges[mud.ChangeChanged],
+		"removed", numChanges[mud.ChangeRemoved],
+		"len callData", len(deltaCallData))
+	if err := writeLineToFile(c.String(deltaOutFlag), deltaCallData); err != nil {
+		l.Errorw("cannot write delta call data to file", "err", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	calldata "github.com/ftk/post-deploy/call-data"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+const eventsOutFlag = "events-out"
+
+func eventsCommand() cli.Command {
+	return cli.Command{
+		Name:  "events",
+		Usage: "build one calldata file per scheduled event start and end",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  testFlag,
+				Usage: "to use the test data config",
+			},
+			cli.StringFlag{
+				Name:  eventsOutFlag,
+				Usage: "directory of the event calldata files",
+				Value: "../../events",
+			},
+		},
+		Action: runEvents,
+	}
+}
+
+// runEvents writes <unix time>_<end|start>_event<id>.txt files and their manifests, so that sorting
+// the file names gives the order to send them
+func runEvents(c *cli.Context) error {
+	l := zap.S().With("func", "runEvents")
+	dataConfig, err := getDataConfig(c.Bool(testFlag))
+	if err != nil {
+		l.Errorw("cannot get data config", "err", err)
+		return err
+	}
+	eventCallDatas, err := calldata.BuildEventData(l, dataConfig)
+	if err != nil {
+		return err
+	}
+	outDir := c.String(eventsOutFlag)
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		l.Errorw("cannot create events directory", "err", err)
+		return err
+	}
+	for _, e := range eventCallDatas {
+		fileName := fmt.Sprintf("%d_%s_event%d.txt", e.Time, e.Phase, e.EventId)
+		filePath := filepath.Join(outDir, fileName)
+		section := calldata.SectionCallData{
+			Name:     fmt.Sprintf("event%d_%s", e.EventId, e.Phase),
+			Source:   "events.json",
+			CallData: e.CallData,
+		}
+		if err := writeCallDataFile(filePath, []calldata.SectionCallData{section}); err != nil {
+			l.Errorw("cannot write event call data", "file", filePath, "err", err)
+			return err
+		}
+		l.Infow("event call data", "file", fileName, "sendAt", time.Unix(e.Time, 0).UTC().Format(time.RFC3339))
+	}
+	return nil
+}
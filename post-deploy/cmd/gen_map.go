@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+
+	calldata "github.com/ftk/post-deploy/call-data"
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+const regenerateFlag = "regenerate"
+
+func genMapCommand() cli.Command {
+	return cli.Command{
+		Name: "gen-map",
+		Usage: "generate the missing tileInfos_<kingdom>.json and monsterLocations_<kingdom>.json caches, " +
+			"then write the monster locations cache and front-end file into data-config",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  regenerateFlag,
+				Usage: "ignore the existing caches and generate the whole map again",
+			},
+		},
+		Action: runGenMap,
+	}
+}
+
+func exportFECommand() cli.Command {
+	return cli.Command{
+		Name:   "export-fe",
+		Usage:  "write the front-end monsterLocations.json into data-config from the cached map data",
+		Action: runExportFE,
+	}
+}
+
+func runGenMap(c *cli.Context) error {
+	l := zap.S().With("func", "runGenMap")
+	var (
+		cacheTileInfos        []common.TileInfo
+		cacheMonsterLocations []common.MonsterLocation
+	)
+	if !c.Bool(regenerateFlag) {
+		mapConfig, err := getMapConfig()
+		if err != nil {
+			l.Errorw("cannot get map config", "err", err)
+			return err
+		}
+		cacheTileInfos, cacheMonsterLocations, err = getAllCachedDeployData(mapConfig)
+		if err != nil {
+			l.Errorw("cannot get full cached data", "err", err)
+			return err
+		}
+	}
+	monsterLocations, err := buildMapData(cacheTileInfos, cacheMonsterLocations)
+	if err != nil {
+		return err
+	}
+	if err := writeMonsterLocationsCache(l, monsterLocations); err != nil {
+		return err
+	}
+	return writeFEMonsterLocations(l, monsterLocations)
+}
+
+func runExportFE(c *cli.Context) error {
+	l := zap.S().With("func", "runExportFE")
+	mapConfig, err := getMapConfig()
+	if err != nil {
+		l.Errorw("cannot get map config", "err", err)
+		return err
+	}
+	cacheTileInfos, cacheMonsterLocations, err := getAllCachedDeployData(mapConfig)
+	if err != nil {
+		l.Errorw("cannot get full cached data", "err", err)
+		return err
+	}
+	if len(cacheTileInfos) == 0 || len(cacheMonsterLocations) == 0 {
+		return errors.New("no cached map data, run gen-map first")
+	}
+	monsterLocations, err := buildMapData(cacheTileInfos, cacheMonsterLocations)
+	if err != nil {
+		return err
+	}
+	return writeFEMonsterLocations(l, monsterLocations)
+}
+
+// buildMapData runs the tileInfo section like build does, generating the map data that is not cached,
+// and returns the monster locations with the overrides and bosses of the data config
+func buildMapData(
+	cacheTileInfos []common.TileInfo, cacheMonsterLocations []common.MonsterLocation) ([]common.MonsterLocation, error) {
+	l := zap.S().With("func", "buildMapData")
+	dataConfig, err := getDataConfig(false)
+	if err != nil {
+		l.Errorw("cannot get data config", "err", err)
+		return nil, err
+	}
+	common.InitMapEnums(dataConfig)
+	mapConfig, err := getMapConfig()
+	if err != nil {
+		l.Errorw("cannot get map config", "err", err)
+		return nil, err
+	}
+	mapColor, err := getMapColor()
+	if err != nil {
+		l.Errorw("cannot get map color", "err", err)
+		return nil, err
+	}
+	ctx := &calldata.BuildContext{
+		DataConfig:            dataConfig,
+		MapConfig:             mapConfig,
+		MapColor:              mapColor,
+		CacheTileInfos:        cacheTileInfos,
+		CacheMonsterLocations: cacheMonsterLocations,
+	}
+	if _, err := calldata.BuildSectionData(l, ctx, []string{"tileInfo"}, nil); err != nil {
+		l.Errorw("cannot build map data", "err", err)
+		return nil, err
+	}
+	return ctx.MonsterLocations, nil
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
+	"strings"
 
 	"github.com/ftk/post-deploy/pkg/common"
 	gentile "github.com/ftk/post-deploy/pkg/gen-tile"
@@ -76,6 +79,19 @@ func getMapConfig() ([]gentile.KingdomMap, error) {
 	return result, nil
 }
 
+// getMapColor load the zone rectangles, full_map is the map bounds
+func getMapColor() (common.MapColor, error) {
+	var (
+		result common.MapColor
+		l      = zap.S().With("func", "getMapColor")
+	)
+	if err := common.ParseFile("./mapColor.json", &result); err != nil {
+		l.Errorw("cannot parse mapColor", "err", err)
+		return result, err
+	}
+	return result, nil
+}
+
 func getDataConfig(isTest bool) (common.DataConfig, error) {
 	var (
 		dataConfig common.DataConfig
@@ -95,3 +111,42 @@ func getDataConfig(isTest bool) (common.DataConfig, error) {
 	}
 	return dataConfig, nil
 }
+
+// getDataConfigAtRevision loads the data config committed at a git revision,
+// a file that does not exist at that revision is left empty
+func getDataConfigAtRevision(isTest bool, revision string) (common.DataConfig, error) {
+	var (
+		dataConfig common.DataConfig
+		l          = zap.S().With("func", "getDataConfigAtRevision")
+		dir        = "data-config"
+	)
+	if isTest {
+		dir = "data-config-test"
+	}
+	out, err := exec.Command("git", "-C", "../..", "ls-tree", "--name-only", revision, dir+"/").Output()
+	if err != nil {
+		l.Errorw("cannot list config files", "revision", revision, "err", err)
+		return dataConfig, err
+	}
+	existing := make(map[string]bool)
+	for _, filePath := range strings.Fields(string(out)) {
+		existing[filePath] = true
+	}
+	for _, f := range common.DataConfigFiles {
+		filePath := fmt.Sprintf("%s/%s", dir, f)
+		if !existing[filePath] {
+			l.Warnw("config file does not exist at revision", "revision", revision, "file", filePath)
+			continue
+		}
+		content, err := exec.Command("git", "-C", "../..", "show", revision+":"+filePath).Output()
+		if err != nil {
+			l.Errorw("cannot read config", "revision", revision, "file", filePath, "err", err)
+			return dataConfig, err
+		}
+		if err := json.Unmarshal(content, &dataConfig); err != nil {
+			l.Errorw("cannot parse config", "revision", revision, "file", filePath, "err", err)
+			return dataConfig, err
+		}
+	}
+	return dataConfig, nil
+}
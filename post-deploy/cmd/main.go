@@ -4,25 +4,22 @@ import (
 	"bufio"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
-	"github.com/ftk/post-deploy/pkg/common"
-	onlineconfig "github.com/ftk/post-deploy/pkg/online-config"
+	calldata "github.com/ftk/post-deploy/call-data"
 	"github.com/urfave/cli"
 	"go.uber.org/zap"
 )
 
 const (
-	testFlag                = "test"
-	outFlag                 = "out"
-	out2Flag                = "out2"
-	localFlag               = "local"
-	updateOnlineFlag        = "update-online"
-	dataPercentFlag         = "data-percent"
-	sheetAuthFileFlag       = "sheet-auth"
-	sheetAuthFileValue      = "sheetConfig.json"
-	sheetUrlConfigFileFlag  = "sheet-config"
-	sheetUrlConfigFileValue = "sheetUrlConfig.json"
+	testFlag        = "test"
+	outFlag         = "out"
+	dataPercentFlag = "data-percent"
+	onlyFlag        = "only"
+	skipFlag        = "skip"
 
 	pathToTestFile = "../../post_deploy_test.txt"
 )
@@ -43,132 +40,65 @@ func main() {
 	app := cli.NewApp()
 	app.Name = "data builder"
 	app.Usage = "build post-deploy data"
-	app.Action = run
-
-	app.Flags = append(app.Flags,
-		cli.BoolFlag{
-			Name:  testFlag,
-			Usage: "to build test data",
-		},
-		cli.StringFlag{
-			Name:  outFlag,
-			Usage: "path to output data file",
-			Value: "../../post_deploy.txt",
-		},
-		cli.StringFlag{
-			Name:  out2Flag,
-			Usage: "path to output reserve data file",
-			Value: "../../post_deploy_reserve.txt",
-		},
-		cli.BoolFlag{
-			Name:  updateOnlineFlag,
-			Usage: "fetch online data to update local config",
-		},
-		cli.BoolFlag{
-			Name:  localFlag,
-			Usage: "build a small data for local",
-		},
-		cli.StringFlag{
-			Name:  sheetAuthFileFlag,
-			Usage: "sheet auth file",
-			Value: sheetAuthFileValue,
-		},
-		cli.StringFlag{
-			Name:  sheetUrlConfigFileFlag,
-			Usage: "sheet url config",
-			Value: sheetUrlConfigFileValue,
-		},
-		cli.Int64Flag{
-			Name:  dataPercentFlag,
-			Usage: "percent of big data to be build",
-			Value: 1,
-		})
+	app.Commands = []cli.Command{
+		buildCommand(),
+		buildReserveCommand(),
+		restockCommand(),
+		genMapCommand(),
+		exportFECommand(),
+		syncSheetsCommand(),
+		validateCommand(),
+		diffCommand(),
+		decodeCommand(),
+		eventsCommand(),
+		sendCommand(),
+	}
 	if err := app.Run(os.Args); err != nil {
 		logger.Sugar().Errorw("app error", "err", err)
+		// os.Exit skips the deferred sync, a failing command must exit non zero for CI
+		_ = logger.Sync()
+		os.Exit(1)
 	}
 }
 
-func run(c *cli.Context) error {
-	var (
-		l = zap.S().With("func", "run")
-	)
-	isTest := c.Bool(testFlag)
-	dataConfig, err := getDataConfig(isTest)
-	if err != nil {
-		l.Errorw("cannot get data config", "err", err)
-		return err
+// splitSections parses a comma separated list of section names
+func splitSections(value string) []string {
+	var sections []string
+	for _, section := range strings.Split(value, ",") {
+		if section = strings.TrimSpace(section); section != "" {
+			sections = append(sections, section)
+		}
 	}
-	// l.Infow("data config", "value", dataConfig)
-	// initMapEnums init map enum value in config
-	common.InitMapEnums(dataConfig)
+	return sections
+}
 
-	// mapConfig for the distribution
-	mapConfig, err := getMapConfig()
-	if err != nil {
-		l.Errorw("cannot get data config", "err", err)
-		return err
+// writeCallDataFile writes the calldata of the sections and their manifest next to the file
+func writeCallDataFile(filePath string, sections []calldata.SectionCallData) error {
+	l := zap.S().With("func", "writeCallDataFile")
+	var rawCallDatas [][]byte
+	for _, section := range sections {
+		rawCallDatas = append(rawCallDatas, section.CallData...)
 	}
-	// l.Infow("mapConfig", "value", mapConfig)
-
-	// load data to process (from cache)
-	var (
-		cacheTileInfos        []common.TileInfo
-		cacheMonsterLocations []common.MonsterLocation
-	)
-	if !isTest {
-		if !c.Bool(localFlag) {
-			cacheTileInfos, cacheMonsterLocations, err = getAllCachedDeployData(mapConfig)
-			if err != nil {
-				l.Errorw("cannot get full cached data", "err", err)
-				return err
-			}
-			l.Infow("full data", "len tile infos", len(cacheTileInfos), "len monster location", len(cacheMonsterLocations))
-		} else {
-			cacheTileInfos, cacheMonsterLocations, err = splitDeployData(
-				mapConfig, dataConfig, c.String(out2Flag), false, c.Int64(dataPercentFlag))
-			if err != nil {
-				l.Errorw("cannot get process data", "err", err)
-				return err
-			}
-		}
-		if c.Bool(updateOnlineFlag) {
-			sheetAuthBytes, err := os.ReadFile(c.String(sheetAuthFileFlag))
-			if err != nil {
-				l.Errorw("cannot read sheet auth file", "err", err)
-				return err
-			}
-			sheetUrlConfigBytes, err := os.ReadFile(c.String(sheetUrlConfigFileFlag))
-			if err != nil {
-				l.Errorw("cannot read sheet url config file", "err", err)
-				return err
-			}
-			var sheetUrlConfig common.SheetUrlConfig
-			if err := json.Unmarshal(sheetUrlConfigBytes, &sheetUrlConfig); err != nil {
-				l.Errorw("cannot unmarshal sheet url config", "err", err)
-				return err
-			}
-			l.Infow("sheet url config", "value", sheetUrlConfig)
-			onlineconfig.UpdateDataConfig(&dataConfig, "../..", sheetAuthBytes, sheetUrlConfig) // update config by online data
-		}
+	if err := writeLineToFile(filePath, rawCallDatas); err != nil {
+		return err
 	}
-
-	// buildCallData build post_deploy data
-	rawCallDatas, err := buildCallData(dataConfig, mapConfig, cacheMonsterLocations,
-		cacheTileInfos, isTest)
+	manifest, err := calldata.BuildManifest(filepath.Base(filePath), sections)
 	if err != nil {
-		// l.Errorw("cannot build call data", "err", err)
+		l.Errorw("cannot build manifest", "err", err)
 		return err
 	}
-	// write to file
-	filePath := c.String(outFlag)
-	if isTest {
-		filePath = pathToTestFile
-	}
-	if err := writeLineToFile(filePath, rawCallDatas); err != nil {
-		l.Errorw("cannot write call data to file", "err", err)
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
 		return err
 	}
-	return nil
+	l.Infow("manifest", "file", manifestPath(filePath), "rootHash", manifest.RootHash,
+		"estimatedGas", manifest.EstimatedGas)
+	return os.WriteFile(manifestPath(filePath), manifestBytes, 0644)
+}
+
+// manifestPath is post_deploy_manifest.json for post_deploy.txt
+func manifestPath(filePath string) string {
+	return strings.TrimSuffix(filePath, filepath.Ext(filePath)) + "_manifest.json"
 }
 
 func writeLineToFile(filepath string, rawCallDatas [][]byte) error {
@@ -189,3 +119,27 @@ func writeLineToFile(filepath string, rawCallDatas [][]byte) error {
 	}
 	return w.Flush()
 }
+
+// readLinesFromFile reads the calldata of a file written by writeLineToFile
+func readLinesFromFile(filepath string) ([][]byte, error) {
+	f, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var callDatas [][]byte
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 16*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		raw := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "0x")
+		if raw == "" {
+			continue
+		}
+		callData, err := hex.DecodeString(raw)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		callDatas = append(callDatas, callData)
+	}
+	return callDatas, scanner.Err()
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMainExitStatus runs main in a child process, CI relies on a failing command exiting non zero
+func TestMainExitStatus(t *testing.T) {
+	if args := os.Getenv("POST_DEPLOY_MAIN_ARGS"); args != "" {
+		os.Args = append([]string{"post-deploy"}, strings.Fields(args)...)
+		main()
+		return
+	}
+	tests := []struct {
+		name string
+		args string
+	}{
+		{name: "validate without data config", args: "validate --test"},
+		{name: "send without rpc", args: "send --in missing.txt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(os.Args[0], "-test.run=^TestMainExitStatus$")
+			// an empty directory has no data config relative to it
+			cmd.Dir = t.TempDir()
+			cmd.Env = append(os.Environ(), "POST_DEPLOY_MAIN_ARGS="+tt.args, "RPC_URL=", "PRIVATE_KEY=")
+			err := cmd.Run()
+			var exitErr *exec.ExitError
+			require.True(t, errors.As(err, &exitErr), "command exited with %v", err)
+			require.Equal(t, 1, exitErr.ExitCode())
+		})
+	}
+}
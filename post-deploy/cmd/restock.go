@@ -0,0 +1,45 @@
+package main
+
+import (
+	calldata "github.com/ftk/post-deploy/call-data"
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+func restockCommand() cli.Command {
+	return cli.Command{
+		Name:  "restock",
+		Usage: "build the calldata resetting the npc shop stock that has the always restock policy",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  testFlag,
+				Usage: "to use the test data config",
+			},
+			cli.StringFlag{
+				Name:  outFlag,
+				Usage: "path to output restock data file",
+				Value: "../../post_deploy_restock.txt",
+			},
+		},
+		Action: runRestock,
+	}
+}
+
+func runRestock(c *cli.Context) error {
+	l := zap.S().With("func", "runRestock")
+	dataConfig, err := getDataConfig(c.Bool(testFlag))
+	if err != nil {
+		l.Errorw("cannot get data config", "err", err)
+		return err
+	}
+	common.InitMapEnums(dataConfig)
+	restockData, err := calldata.BuildNpcShopRestockData(l, dataConfig)
+	if err != nil {
+		return err
+	}
+	l.Infow("npc shop restock", "len callData", len(restockData))
+	return writeCallDataFile(c.String(outFlag), []calldata.SectionCallData{
+		{Name: "npcShopRestock", Source: "map.json", CallData: restockData},
+	})
+}
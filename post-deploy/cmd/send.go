@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	calldata "github.com/ftk/post-deploy/call-data"
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/ftk/post-deploy/pkg/transactor"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+const (
+	rpcFlag        = "rpc"
+	worldFlag      = "world"
+	privateKeyFlag = "private-key"
+	fromLineFlag   = "from-line"
+	noVerifyFlag   = "no-verify"
+)
+
+func sendCommand() cli.Command {
+	return cli.Command{
+		Name:  "send",
+		Usage: "send each line of a calldata file as a transaction to the world",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  inFlag,
+				Usage: "path to the calldata file to send",
+				Value: "../../post_deploy.txt",
+			},
+			cli.StringFlag{
+				Name:  manifestFlag,
+				Usage: "path to the manifest the file is checked against before sending, <in>_manifest.json by default",
+			},
+			cli.BoolFlag{
+				Name:  noVerifyFlag,
+				Usage: "send the file without checking it against its manifest",
+			},
+			cli.StringFlag{
+				Name:   rpcFlag,
+				Usage:  "rpc endpoint of the chain",
+				EnvVar: "RPC_URL",
+			},
+			cli.StringFlag{
+				Name:   worldFlag,
+				Usage:  "world contract address",
+				EnvVar: "WORLD_ADDRESS",
+			},
+			cli.StringFlag{
+				Name:   privateKeyFlag,
+				Usage:  "private key of the sender, prefer the env var over the flag",
+				EnvVar: "PRIVATE_KEY",
+			},
+			cli.IntFlag{
+				Name:  fromLineFlag,
+				Usage: "1-based line to start from, to resume an interrupted send",
+				Value: 1,
+			},
+		},
+		Action: runSend,
+	}
+}
+
+func runSend(c *cli.Context) error {
+	l := zap.S().With("func", "runSend")
+	if c.String(rpcFlag) == "" || c.String(privateKeyFlag) == "" {
+		return errors.New("rpc and private key are required")
+	}
+	if !ethcommon.IsHexAddress(c.String(worldFlag)) {
+		return fmt.Errorf("invalid world address %q", c.String(worldFlag))
+	}
+	filePath := c.String(inFlag)
+	callDatas, err := readLinesFromFile(filePath)
+	if err != nil {
+		l.Errorw("cannot read calldata file", "err", err)
+		return err
+	}
+	fromLine := c.Int(fromLineFlag)
+	if fromLine < 1 || fromLine > len(callDatas) {
+		return fmt.Errorf("from line %d out of range, the file has %d lines", fromLine, len(callDatas))
+	}
+	if !c.Bool(noVerifyFlag) {
+		path := c.String(manifestFlag)
+		if path == "" {
+			path = manifestPath(filePath)
+		}
+		if _, err := os.Stat(path); err != nil {
+			l.Errorw("cannot find manifest, use --no-verify to send anyway", "err", err)
+			return err
+		}
+		var manifest calldata.Manifest
+		if err := common.ParseFile(path, &manifest); err != nil {
+			l.Errorw("cannot parse manifest", "err", err)
+			return err
+		}
+		if err := manifest.Verify(callDatas); err != nil {
+			l.Errorw("file does not match manifest", "err", err)
+			return err
+		}
+		l.Infow("file matches manifest", "rootHash", manifest.RootHash, "estimatedGas", manifest.EstimatedGas)
+	}
+
+	t := transactor.NewTransactor(c.String(rpcFlag), ethcommon.HexToAddress(c.String(worldFlag)), c.String(privateKeyFlag))
+	if err := t.Execute(callDatas, fromLine-1); err != nil {
+		l.Errorw("cannot send calldata", "err", err)
+		return err
+	}
+	l.Infow("sent calldata", "file", filePath, "from line", fromLine, "len calldata", len(callDatas)-fromLine+1)
+	return nil
+}
@@ -88,7 +88,10 @@ func splitDeployData(
 		reserveMonsterLocations = append(reserveMonsterLocations, cReserveMonsterLocations...)
 	}
 	if buildReserveData {
-		writeReserveData(reserveTileInfos, reserveMonsterLocations, dataConfig, reserveOutPutPath)
+		if err := writeReserveData(reserveTileInfos, reserveMonsterLocations, dataConfig, reserveOutPutPath); err != nil {
+			l.Errorw("cannot write reserve data", "err", err)
+			return nil, nil, err
+		}
 	}
 	return processTileInfos, processMonsterLocations, nil
 }
@@ -99,8 +102,8 @@ func writeReserveData(
 	dataConfig common.DataConfig,
 	reserveOutPutPath string) error {
 	var (
-		callData [][]byte
-		l        = zap.S().With("func", "writeReserveData")
+		tileInfoData [][]byte
+		l            = zap.S().With("func", "writeReserveData")
 	)
 	for _, ti := range tileInfos {
 		tileInfoCallData, err := table.TileInfoCallData(ti, dataConfig)
@@ -108,7 +111,7 @@ func writeReserveData(
 			l.Errorw("cannot build TileInfo call data", "err", err)
 			return err
 		}
-		callData = append(callData, tileInfoCallData)
+		tileInfoData = append(tileInfoData, tileInfoCallData)
 	}
 
 	monsterLocationData, err := calldata.BuildMonsterLocationData(l, monsterLocations)
@@ -116,8 +119,10 @@ func writeReserveData(
 		l.Errorw("cannot build monsterLocationData", "err", err)
 		return err
 	}
-	callData = append(callData, monsterLocationData...)
-	return writeLineToFile(reserveOutPutPath, callData)
+	return writeCallDataFile(reserveOutPutPath, []calldata.SectionCallData{
+		{Name: "tileInfo", Source: "tileInfos.json", CallData: tileInfoData},
+		{Name: "monsterLocation", Source: "monsterLocationsCache.json", CallData: monsterLocationData},
+	})
 }
 
 func splitMonster(
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	onlineconfig "github.com/ftk/post-deploy/pkg/online-config"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+const (
+	sheetAuthFileFlag       = "sheet-auth"
+	sheetAuthFileValue      = "sheetConfig.json"
+	sheetUrlConfigFileFlag  = "sheet-config"
+	sheetUrlConfigFileValue = "sheetUrlConfig.json"
+)
+
+func syncSheetsCommand() cli.Command {
+	return cli.Command{
+		Name:  "sync-sheets",
+		Usage: "fetch the online sheets and update the data-config files with them",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  sheetAuthFileFlag,
+				Usage: "sheet auth file",
+				Value: sheetAuthFileValue,
+			},
+			cli.StringFlag{
+				Name:  sheetUrlConfigFileFlag,
+				Usage: "sheet url config",
+				Value: sheetUrlConfigFileValue,
+			},
+		},
+		Action: runSyncSheets,
+	}
+}
+
+func runSyncSheets(c *cli.Context) error {
+	l := zap.S().With("func", "runSyncSheets")
+	dataConfig, err := getDataConfig(false)
+	if err != nil {
+		l.Errorw("cannot get data config", "err", err)
+		return err
+	}
+	common.InitMapEnums(dataConfig)
+	sheetAuthBytes, err := os.ReadFile(c.String(sheetAuthFileFlag))
+	if err != nil {
+		l.Errorw("cannot read sheet auth file", "err", err)
+		return err
+	}
+	sheetUrlConfigBytes, err := os.ReadFile(c.String(sheetUrlConfigFileFlag))
+	if err != nil {
+		l.Errorw("cannot read sheet url config file", "err", err)
+		return err
+	}
+	var sheetUrlConfig common.SheetUrlConfig
+	if err := json.Unmarshal(sheetUrlConfigBytes, &sheetUrlConfig); err != nil {
+		l.Errorw("cannot unmarshal sheet url config", "err", err)
+		return err
+	}
+	l.Infow("sheet url config", "value", sheetUrlConfig)
+	onlineconfig.UpdateDataConfig(&dataConfig, "../..", sheetAuthBytes, sheetUrlConfig) // update config by online data
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+
+	calldata "github.com/ftk/post-deploy/call-data"
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+func validateCommand() cli.Command {
+	return cli.Command{
+		Name:  "validate",
+		Usage: "check every reference of the data config, exit with code 1 if there is a problem",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  testFlag,
+				Usage: "to use the test data config",
+			},
+		},
+		Action: runValidate,
+	}
+}
+
+func runValidate(c *cli.Context) error {
+	l := zap.S().With("func", "runValidate")
+	isTest := c.Bool(testFlag)
+	dataConfig, err := getDataConfig(isTest)
+	if err != nil {
+		l.Errorw("cannot get data config", "err", err)
+		return err
+	}
+	mapColor, err := getMapColor()
+	if err != nil {
+		l.Errorw("cannot get map color", "err", err)
+		return err
+	}
+	// the map data the overlays are checked against, like the build uses it
+	tileInfos := dataConfig.TileInfos
+	monsterLocations := append(append([]common.MonsterLocation{}, dataConfig.MonsterLocationsOverride...),
+		dataConfig.MonsterLocationsBoss...)
+	if !isTest {
+		mapConfig, err := getMapConfig()
+		if err != nil {
+			l.Errorw("cannot get map config", "err", err)
+			return err
+		}
+		tileInfos, monsterLocations, err = getAllCachedDeployData(mapConfig)
+		if err != nil {
+			l.Errorw("cannot get full cached data", "err", err)
+			return err
+		}
+	}
+
+	problems := calldata.ValidateDataConfig(dataConfig, mapColor, tileInfos, monsterLocations)
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if len(problems) > 0 {
+		return cli.NewExitError(fmt.Sprintf("%d problems in the data config", len(problems)), 1)
+	}
+	l.Infow("data config is valid")
+	return nil
+}
@@ -8,5 +8,6 @@ var (
 		"tileInfos.json", "types.json", "welcomeConfig.json", "skills.json",
 		"monsters.json", "monsterLocationsCache.json", "monsterLocationsOverride.json", "monsterLocationsBoss.json",
 		"achievements.json", "itemExchanges.json", "petComponentRates.json",
+		"gachas.json", "gameConfig.json", "events.json", "cityLevelRequires.json", "salePackages.json",
 	}
 )
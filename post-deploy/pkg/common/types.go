@@ -34,6 +34,8 @@ type Item struct {
 	ExpAmplify    *ExpAmplify    `json:"expAmplify,omitempty"`
 	StatsModify   *StatsModify   `json:"statsModify,omitempty"`
 	SkinInfo      *SkinInfo      `json:"skinInfo,omitempty"`
+	// only for items of type StatModifierItem
+	StatModifierInfo *StatModifierItemInfo `json:"statModifierInfo,omitempty"`
 }
 
 type BuffItemInfo struct {
@@ -85,6 +87,19 @@ type EquipmentInfo struct {
 	Ms            int  `json:"ms"`
 	BonusWeight   int  `json:"weight"`
 	ShieldBarrier int  `json:"barrier"`
+	// upgrade fields, only for the slot types a character can grind with
+	MaxLevel   uint8  `json:"maxLevel,omitempty"`
+	Counter    uint8  `json:"counter,omitempty"`
+	DmgPercent uint16 `json:"dmgPercent,omitempty"`
+}
+
+// StatModifierItemInfo is a consumable changing the stats of a character for Duration seconds
+type StatModifierItemInfo struct {
+	Duration   uint16 `json:"duration"`
+	AtkPercent int16  `json:"atkPercent"`
+	DefPercent int16  `json:"defPercent"`
+	AgiPercent int16  `json:"agiPercent"`
+	Ms         int16  `json:"ms"`
 }
 
 type HealingInfo struct {
@@ -129,18 +144,58 @@ type City struct {
 	Name      string `json:"name"`
 	IsCapital bool   `json:"isCapital"`
 	Level     uint8  `json:"level"`
+	// ShopStock is the starting inventory of the city npc shop
+	ShopStock []NpcShopStock `json:"shopStock,omitempty"`
+}
+
+type NpcShopRestockPolicy string
+
+const (
+	// NpcShopRestockInitial stock is only written by the full deploy
+	NpcShopRestockInitial NpcShopRestockPolicy = "initial"
+	// NpcShopRestockAlways stock is also reset to the amount by every restock
+	NpcShopRestockAlways NpcShopRestockPolicy = "always"
+)
+
+type NpcShopStock struct {
+	ItemId  int                  `json:"itemId"`
+	Amount  uint32               `json:"amount"`
+	Restock NpcShopRestockPolicy `json:"restock"`
 }
 
 type Kingdom struct {
-	Id              uint8          `json:"id"`
-	Name            string         `json:"name"`
-	SubTitle        string         `json:"subTitle"`
-	Desc            string         `json:"desc"`
-	MainResources   []ResourceType `json:"mainResources"`
-	Color           string         `json:"color"`
-	CapitalId       int            `json:"capitalId"`
-	CapitalName     string         `json:"capitalName"`
-	CapitalPosition Location       `json:"capitalPosition"`
+	Id              uint8           `json:"id"`
+	Name            string          `json:"name"`
+	SubTitle        string          `json:"subTitle"`
+	Desc            string          `json:"desc"`
+	MainResources   []ResourceType  `json:"mainResources"`
+	Color           string          `json:"color"`
+	CapitalId       int             `json:"capitalId"`
+	CapitalName     string          `json:"capitalName"`
+	CapitalPosition Location        `json:"capitalPosition"`
+	Economy         *KingdomEconomy `json:"economy,omitempty"`
+}
+
+// KingdomEconomy is the initial value of the settings a king can change in game
+type KingdomEconomy struct {
+	// map kingdomId => market fee percent paid by its citizens in the markets of this kingdom
+	MarketFees          map[string]uint8 `json:"marketFees"`
+	CrystalFee          uint8            `json:"crystalFee"`          // percent
+	PvpFamePenalty      uint16           `json:"pvpFamePenalty"`      // killing an ally
+	CaptureTilePenalty  uint16           `json:"captureTilePenalty"`  // capturing a tile of an ally
+	WithdrawWeightLimit uint32           `json:"withdrawWeightLimit"` // daily treasury withdraw
+}
+
+// Diplomacy is the initial relation between kingdoms at the start of a season
+type Diplomacy struct {
+	Alliances []Alliance `json:"alliances"`
+}
+
+type Alliance struct {
+	KingdomA uint8 `json:"kingdomA"`
+	KingdomB uint8 `json:"kingdomB"`
+	// optional market fee both kingdoms charge each other's citizens, overrides the economy market fees
+	MarketFee *uint8 `json:"marketFee,omitempty"`
 }
 
 type Location struct {
@@ -160,6 +215,25 @@ type Ingredient struct {
 	Amount int `json:"amount"`
 }
 
+// CityLevelRequire is the resources a city vault spends to upgrade to Level
+type CityLevelRequire struct {
+	Level     uint8        `json:"level"`
+	Resources []Ingredient `json:"resources"`
+}
+
+// SalePackage is a package of SaleSystem sold for crystals or, if crystalPrice is 0, for gold
+type SalePackage struct {
+	Id             int      `json:"id"`
+	CrystalPrice   uint32   `json:"crystalPrice"`
+	GoldPrice      uint32   `json:"goldPrice"`
+	Gold           uint32   `json:"gold"`
+	AchievementIds []int    `json:"achievementIds"`
+	ItemIds        []int    `json:"itemIds"`
+	ItemAmounts    []uint32 `json:"itemAmounts"`
+	// the bonus items are meant to be resold on the market, untradable items are rejected
+	RequireTradable bool `json:"requireTradable"`
+}
+
 type ItemRecipe struct {
 	ItemId             int          `json:"itemId"`
 	PerkItemTypes      []int        `json:"perkTypes,omitempty"`
@@ -180,7 +254,7 @@ type Npc struct {
 	X      int32     `json:"x"`
 	Y      int32     `json:"y"`
 	Name   string    `json:"name"`
-	Cards  []NpcCard `json:"cards"`
+	Cards  []NpcCard `json:"cards"` // not written on-chain, no contract table holds npc decks
 }
 
 type NpcCard struct {
@@ -350,25 +424,64 @@ type PetComponents struct {
 	CpnRatios []uint16 `json:"cpnRatios"`
 }
 
+// Gacha banner, a character pays either the ticket item or ticketValue crystal
+type Gacha struct {
+	Id           int         `json:"id"`
+	StartTime    int64       `json:"startTime"`   // unix seconds
+	TicketValue  uint32      `json:"ticketValue"` // crystal, 0 if only the ticket item is accepted
+	TicketItemId int         `json:"ticketItemId"`
+	Items        []GachaItem `json:"items"`
+}
+
+type GachaItem struct {
+	ItemId  int    `json:"itemId"`
+	Amount  uint32 `json:"amount"`
+	Percent uint16 `json:"percent"` // total is 10000, min is 1 (0.01%)
+}
+
+type EventType string
+
+const (
+	EventTypeExpAmp EventType = "expAmp" // global exp amplifier from start to end
+	EventTypeGacha  EventType = "gacha"  // gacha banner opened at start and closed at end
+)
+
+// Event is a scheduled live-ops event, times are unix seconds
+type Event struct {
+	Id        int           `json:"id"`
+	Name      string        `json:"name"`
+	Type      EventType     `json:"type"`
+	StartTime int64         `json:"startTime"`
+	EndTime   int64         `json:"endTime"`
+	ExpAmp    *ExpAmpConfig `json:"expAmp,omitempty"`  // expireTime is ignored, the event end is used
+	GachaId   int           `json:"gachaId,omitempty"` // id in gachas.json
+}
+
 type DataConfig struct {
-	Achievements             map[string]Achievement  `json:"achievements"` // map id => Achievement
-	Items                    map[string]Item         `json:"items"`        // map itemId => Item
-	WelcomeConfig            WelcomeConfig           `json:"welcomeConfig"`
-	CharacterQuestions       []CharacterQuestion     `json:"characterQuestions"`
-	Cities                   map[string]City         `json:"cities"`
-	Kingdoms                 map[string]Kingdom      `json:"kingdoms"`
-	Npcs                     map[string]Npc          `json:"npcs"`
-	TileInfos                []TileInfo              `json:"tileInfos"`
-	ItemRecipes              map[string]ItemRecipe   `json:"itemRecipes"`
-	DailyQuestConfig         DailyQuestConfig        `json:"dailyQuestConfig"`
-	Quests                   map[string]QuestV4      `json:"quests"`
-	Skills                   map[string]Skill        `json:"skills"`
-	Monsters                 map[string]Monster      `json:"monsters"`
-	MonsterLocationsCache    []MonsterLocation       `json:"monsterLocationsCache"`
-	MonsterLocationsOverride []MonsterLocation       `json:"monsterLocationsOverride"`
-	MonsterLocationsBoss     []MonsterLocation       `json:"monsterLocationsBoss"`
-	ItemExchanges            map[string]ItemExchange `json:"itemExchanges"`
-	PetComponentRates        map[string]PetCpnRate   `json:"petComponentRates"`
+	Achievements             map[string]Achievement      `json:"achievements"` // map id => Achievement
+	Items                    map[string]Item             `json:"items"`        // map itemId => Item
+	WelcomeConfig            WelcomeConfig               `json:"welcomeConfig"`
+	CharacterQuestions       []CharacterQuestion         `json:"characterQuestions"`
+	Cities                   map[string]City             `json:"cities"`
+	Kingdoms                 map[string]Kingdom          `json:"kingdoms"`
+	Diplomacy                Diplomacy                   `json:"diplomacy"`
+	Npcs                     map[string]Npc              `json:"npcs"`
+	TileInfos                []TileInfo                  `json:"tileInfos"`
+	ItemRecipes              map[string]ItemRecipe       `json:"itemRecipes"`
+	DailyQuestConfig         DailyQuestConfig            `json:"dailyQuestConfig"`
+	Quests                   map[string]QuestV4          `json:"quests"`
+	Skills                   map[string]Skill            `json:"skills"`
+	Monsters                 map[string]Monster          `json:"monsters"`
+	MonsterLocationsCache    []MonsterLocation           `json:"monsterLocationsCache"`
+	MonsterLocationsOverride []MonsterLocation           `json:"monsterLocationsOverride"`
+	MonsterLocationsBoss     []MonsterLocation           `json:"monsterLocationsBoss"`
+	ItemExchanges            map[string]ItemExchange     `json:"itemExchanges"`
+	PetComponentRates        map[string]PetCpnRate       `json:"petComponentRates"`
+	Gachas                   map[string]Gacha            `json:"gachas"` // map id => Gacha
+	GameConfig               GameConfig                  `json:"gameConfig"`
+	Events                   map[string]Event            `json:"events"`            // map id => Event
+	CityLevelRequires        map[string]CityLevelRequire `json:"cityLevelRequires"` // map level => CityLevelRequire
+	SalePackages             map[string]SalePackage      `json:"salePackages"`      // map id => SalePackage
 
 	// enum type
 	ResourceTypes      map[ResourceType]int       `json:"resourceTypes"`      // enums
@@ -399,10 +512,44 @@ var (
 	MapSkinSlotTypes       = make(map[int]SkinSlotType)
 )
 
+// MapColor has the zone rectangles, each one is 4 corners clockwise from the top left.
+// Black and greens are the colors of the front-end map, their tiles have monsters and resources
+// so no overlay is derived from them. Overlays are only the tiles listed in their own keys,
+// the test build reads the same file.
 type MapColor struct {
 	FullMap [4]Location            `json:"full_map"`
 	Black   [4]Location            `json:"black"`
 	Greens  map[string][4]Location `json:"greens"`
+	// overlays
+	SafeZones map[string][][4]Location `json:"safe_zones,omitempty"` // map cityId => RestrictLocV2
+	Blocked   [][4]Location            `json:"blocked,omitempty"`    // Unmovable terrain
+	Neutral   [][4]Location            `json:"neutral,omitempty"`    // NonOccupyTile
+}
+
+// GameConfig has one section per singleton config table, a missing section is not set
+type GameConfig struct {
+	Inventory *InventoryConfig `json:"inventory,omitempty"`
+	Movement  *MovementConfig  `json:"movement,omitempty"`
+	ExpAmp    *ExpAmpConfig    `json:"expAmp,omitempty"`
+}
+
+type InventoryConfig struct {
+	BaseWeight uint32 `json:"baseWeight"`
+	MaxWeight  uint32 `json:"maxWeight"`
+}
+
+type MovementConfig struct {
+	BaseMovementSpeed uint16 `json:"baseMovementSpeed"`
+	MaxMovementSpeed  uint16 `json:"maxMovementSpeed"`
+	Duration          uint16 `json:"duration"` // seconds
+}
+
+// ExpAmpConfig is the global exp amplifier, amps are percentages e.g. 20 means gain 20% more exp
+type ExpAmpConfig struct {
+	FarmingPerkAmp uint16 `json:"farmingPerkAmp"`
+	PveExpAmp      uint16 `json:"pveExpAmp"`
+	PvePerkAmp     uint16 `json:"pvePerkAmp"`
+	ExpireTime     int64  `json:"expireTime"` // unix seconds
 }
 
 // SheetUrlConfig contains the sheet ID for each data type in google sheet
@@ -412,6 +559,7 @@ type SheetUrlConfig struct {
 	ListFarmingResourceUpdate  int64  `json:"listFarmingResourceUpdate"`
 	ListEquipmentUpdate        int64  `json:"listEquipmentUpdate"`
 	ListHealingItemUpdate      int64  `json:"listHealingItemUpdate"`
+	ListStatModifierItemUpdate int64  `json:"listStatModifierItemUpdate"` // 0 if not synced
 	ListScrollUpdate           int64  `json:"listScrollUpdate"`
 	ListToolUpdate             int64  `json:"listToolUpdate"`
 	ListCardUpdate             int64  `json:"listCardUpdate"`
@@ -420,4 +568,6 @@ type SheetUrlConfig struct {
 	ListSkillUpdate            int64  `json:"listSkillUpdate"`
 	ListItemExUpdate           int64  `json:"listItemExUpdate"`
 	ListPetComponentRateUpdate int64  `json:"listPetComponentRateUpdate"`
+	ListCityLevelRequireUpdate int64  `json:"listCityLevelRequireUpdate"` // 0 if not synced
+	ListSalePackageUpdate      int64  `json:"listSalePackageUpdate"`      // 0 if not synced
 }
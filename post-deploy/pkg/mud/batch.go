@@ -0,0 +1,189 @@
+package mud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+const (
+	TX_BASE_GAS          = 21_000
+	CALLDATA_ZERO_GAS    = 4
+	CALLDATA_NONZERO_GAS = 16
+	// CALL_OVERHEAD_GAS covers world routing, access control and schema reads of one store call
+	CALL_OVERHEAD_GAS = 30_000
+	SSTORE_SET_GAS    = 22_100
+	SSTORE_RESET_GAS  = 5_000
+	LOG_DATA_GAS      = 8
+	WORD_SIZE         = 32
+)
+
+// SystemCallData is one entry of World.batchCall
+type SystemCallData struct {
+	SystemId ResourceId `abi:"systemId"`
+	CallData []byte     `abi:"callData"`
+}
+
+// BatchConfig is the budget of one batched transaction
+type BatchConfig struct {
+	// SystemId is the helper system that receives each store call, it must expose the
+	// World store functions (setRecord, setStaticField, ...) and be registered as root
+	// so that it writes with the World storage, like src/systems/PostDeploySystem.sol
+	SystemId ResourceId
+	// GasLimit is the max estimated gas of a batch transaction
+	GasLimit uint64
+	// MaxCalldataSize is the max byte length of a batch transaction calldata
+	MaxCalldataSize int
+}
+
+// Batch is one batchCall transaction, Records are the indexes of the input calls it contains
+type Batch struct {
+	CallData     []byte
+	Records      []int
+	EstimatedGas uint64
+}
+
+// CalldataGas returns the intrinsic gas paid for the calldata bytes
+func CalldataGas(callData []byte) uint64 {
+	var gas uint64
+	for _, b := range callData {
+		if b == 0 {
+			gas += CALLDATA_ZERO_GAS
+		} else {
+			gas += CALLDATA_NONZERO_GAS
+		}
+	}
+	return gas
+}
+
+// EstimateGas roughly estimates the execution gas of one store call built by MudTable,
+// without the transaction base cost. Every written word is priced as a fresh storage slot
+// so the estimate errs on the high side for updates.
+func EstimateGas(callData []byte) (uint64, error) {
+	method, args, err := unpackCall(callData)
+	if err != nil {
+		return 0, err
+	}
+	var writtenBytes int
+	switch method.Name {
+	case "setRecord":
+		writtenBytes = len(args[2].([]byte)) + len(args[4].([]byte))
+		if len(args[4].([]byte)) > 0 {
+			writtenBytes += WORD_SIZE // packed counter
+		}
+	case "setStaticField", "setField", "spliceStaticData":
+		writtenBytes = len(args[3].([]byte))
+	case "setDynamicField", "pushToDynamicField":
+		writtenBytes = len(args[3].([]byte)) + WORD_SIZE
+	case "spliceDynamicData":
+		writtenBytes = len(args[5].([]byte)) + WORD_SIZE
+	case "popFromDynamicField":
+		writtenBytes = WORD_SIZE
+	case "deleteRecord":
+		return CALL_OVERHEAD_GAS + CalldataGas(callData) + 2*SSTORE_RESET_GAS, nil
+	default:
+		return 0, fmt.Errorf("unsupported method %s", method.Name)
+	}
+	words := uint64((writtenBytes + WORD_SIZE - 1) / WORD_SIZE)
+	return CALL_OVERHEAD_GAS + CalldataGas(callData) + words*SSTORE_SET_GAS +
+		uint64(len(callData))*LOG_DATA_GAS, nil
+}
+
+// batchEntrySize is the abi encoded size of one SystemCallData inside batchCall:
+// tuple offset, systemId, bytes offset, bytes length and the padded bytes
+func batchEntrySize(callData []byte) int {
+	return 4*WORD_SIZE + (len(callData)+WORD_SIZE-1)/WORD_SIZE*WORD_SIZE
+}
+
+// BatchCalls packs store calls into batchCall transactions in order,
+// a new batch is started when the next call would exceed the gas or calldata budget.
+// A call that exceeds the budget alone is put in its own batch.
+func BatchCalls(callDatas [][]byte, config BatchConfig) ([]Batch, error) {
+	if config.SystemId.Type() != RESOURCE_SYSTEM {
+		return nil, fmt.Errorf("batch target %s is not a system", config.SystemId)
+	}
+	contract, err := abi.JSON(strings.NewReader(worldABI))
+	if err != nil {
+		return nil, err
+	}
+	var (
+		batches []Batch
+		calls   []SystemCallData
+		current Batch
+		// gas is the running estimate including calldata, it is replaced by
+		// the exact calldata gas once the batch is packed
+		gas     uint64
+		execGas uint64
+		size    int
+	)
+	flush := func() error {
+		if len(calls) == 0 {
+			return nil
+		}
+		packed, err := contract.Pack("batchCall", calls)
+		if err != nil {
+			return err
+		}
+		current.CallData = packed
+		current.EstimatedGas = TX_BASE_GAS + execGas + CalldataGas(packed)
+		batches = append(batches, current)
+		calls = nil
+		current = Batch{}
+		return nil
+	}
+	// selector, array offset and array length
+	emptySize := 4 + 2*WORD_SIZE
+	for index, callData := range callDatas {
+		callGas, err := EstimateGas(callData)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", index, err)
+		}
+		entrySize := batchEntrySize(callData)
+		// the abi wrapping is priced as non zero bytes to stay under the budget
+		entryGas := callGas + uint64(entrySize-len(callData))*CALLDATA_NONZERO_GAS
+		if len(calls) > 0 && (gas+entryGas > config.GasLimit || size+entrySize > config.MaxCalldataSize) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		if len(calls) == 0 {
+			gas = TX_BASE_GAS + uint64(emptySize)*CALLDATA_NONZERO_GAS
+			execGas = 0
+			size = emptySize
+		}
+		calls = append(calls, SystemCallData{SystemId: config.SystemId, CallData: callData})
+		current.Records = append(current.Records, index)
+		gas += entryGas
+		execGas += callGas - CalldataGas(callData)
+		size += entrySize
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return batches, nil
+}
+
+// UnpackBatchCall returns the system calls of batchCall calldata
+func UnpackBatchCall(callData []byte) ([]SystemCallData, error) {
+	contract, err := abi.JSON(strings.NewReader(worldABI))
+	if err != nil {
+		return nil, err
+	}
+	if len(callData) < 4 {
+		return nil, fmt.Errorf("calldata too short")
+	}
+	method, err := contract.MethodById(callData[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(callData[4:])
+	if err != nil {
+		return nil, err
+	}
+	var calls []SystemCallData
+	if err := method.Inputs.Copy(&calls, args); err != nil {
+		return nil, err
+	}
+	return calls, nil
+}
@@ -0,0 +1,65 @@
+package mud
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCalls(t *testing.T) {
+	mt := NewMudTable("TileInfo3", "app", "")
+	callDatas := make([][]byte, 0)
+	for x := int64(-5); x < 5; x++ {
+		keyTuple := [][32]byte{
+			[32]byte(math.U256Bytes(big.NewInt(x))),
+			[32]byte(math.U256Bytes(big.NewInt(1))),
+		}
+		callData, err := mt.SetStaticFieldRawCalldata(keyTuple, 0, []byte{1})
+		require.NoError(t, err)
+		callDatas = append(callDatas, callData)
+	}
+	systemId, err := NewSystemId("app", "PostDeploySystem")
+	require.NoError(t, err)
+
+	// everything fits in one batch
+	batches, err := BatchCalls(callDatas, BatchConfig{SystemId: systemId, GasLimit: 30_000_000, MaxCalldataSize: 128 * 1024})
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	require.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, batches[0].Records)
+	calls, err := UnpackBatchCall(batches[0].CallData)
+	require.NoError(t, err)
+	require.Len(t, calls, len(callDatas))
+	for i, call := range calls {
+		require.Equal(t, systemId, call.SystemId)
+		require.Equal(t, callDatas[i], call.CallData)
+	}
+	size := 4 + 2*WORD_SIZE
+	for _, callData := range callDatas {
+		size += batchEntrySize(callData)
+	}
+	require.Len(t, batches[0].CallData, size)
+
+	// budgets split the calls in order and every record is kept once
+	gasPerCall, err := EstimateGas(callDatas[0])
+	require.NoError(t, err)
+	config := BatchConfig{SystemId: systemId, GasLimit: TX_BASE_GAS + 4*gasPerCall, MaxCalldataSize: 128 * 1024}
+	batches, err = BatchCalls(callDatas, config)
+	require.NoError(t, err)
+	require.Greater(t, len(batches), 2)
+	next := 0
+	for _, batch := range batches {
+		require.LessOrEqual(t, batch.EstimatedGas, config.GasLimit)
+		for _, record := range batch.Records {
+			require.Equal(t, next, record)
+			next++
+		}
+	}
+	require.Equal(t, len(callDatas), next)
+
+	// a call over the budget alone gets its own batch
+	batches, err = BatchCalls(callDatas[:2], BatchConfig{SystemId: systemId, GasLimit: 1, MaxCalldataSize: 1})
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+}
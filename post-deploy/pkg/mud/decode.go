@@ -0,0 +1,235 @@
+package mud
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DecodedField is a field value decoded with the table schema
+type DecodedField struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+	Enum  string      `json:"enum,omitempty"` // enum value name if the field is an enum
+}
+
+// DecodedCall is a World call decoded with the table schema
+type DecodedCall struct {
+	Method    string         `json:"method"`
+	Table     string         `json:"table"`
+	Namespace string         `json:"namespace"`
+	Key       []DecodedField `json:"key"`
+	Fields    []DecodedField `json:"fields"`
+	// Args holds call arguments that are not field values, e.g. splice offsets
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// String returns a single line human-readable form of the call
+func (dc DecodedCall) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s:%s (", dc.Method, dc.Namespace, dc.Table)
+	for i, k := range dc.Key {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(k.String())
+	}
+	b.WriteString(")")
+	for _, f := range dc.Fields {
+		b.WriteString(" ")
+		b.WriteString(f.String())
+	}
+	argNames := make([]string, 0, len(dc.Args))
+	for name := range dc.Args {
+		argNames = append(argNames, name)
+	}
+	sort.Strings(argNames)
+	for _, name := range argNames {
+		fmt.Fprintf(&b, " %s=%v", name, dc.Args[name])
+	}
+	return b.String()
+}
+
+// String returns name=value, enums are shown as Name(value)
+func (df DecodedField) String() string {
+	switch v := df.Value.(type) {
+	case string:
+		return fmt.Sprintf("%s=%q", df.Name, v)
+	}
+	if df.Enum != "" {
+		return fmt.Sprintf("%s=%s(%v)", df.Name, df.Enum, df.Value)
+	}
+	return fmt.Sprintf("%s=%v", df.Name, df.Value)
+}
+
+func unpackCall(callData []byte) (*abi.Method, []interface{}, error) {
+	contract, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(callData) < 4 {
+		return nil, nil, fmt.Errorf("calldata too short")
+	}
+	method, err := contract.MethodById(callData[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+	args, err := method.Inputs.Unpack(callData[4:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return method, args, nil
+}
+
+// TableSchemaByID returns the schema of the table with the given resource id
+func TableSchemaByID(tableId ResourceId) (TableSchema, error) {
+	for _, schema := range Tables {
+		id, err := getTableId(schema.Name, schema.Namespace)
+		if err == nil && id == tableId {
+			return schema, nil
+		}
+	}
+	return TableSchema{}, fmt.Errorf("unknown table %s", tableId)
+}
+
+// DecodeCalldata reverses the calldata built by MudTable
+func DecodeCalldata(callData []byte) (DecodedCall, error) {
+	method, args, err := unpackCall(callData)
+	if err != nil {
+		return DecodedCall{}, err
+	}
+	schema, err := TableSchemaByID(args[0].([32]byte))
+	if err != nil {
+		return DecodedCall{}, err
+	}
+	result := DecodedCall{
+		Method:    method.Name,
+		Table:     schema.Name,
+		Namespace: schema.Namespace,
+	}
+	result.Key, err = decodeKeyTuple(schema, args[1].([][32]byte))
+	if err != nil {
+		return result, err
+	}
+	switch method.Name {
+	case "setRecord":
+		staticValues, err := schema.DecodeStaticData(args[2].([]byte))
+		if err != nil {
+			return result, err
+		}
+		dynamicValues, err := schema.DecodeDynamicData(args[3].([32]byte), args[4].([]byte))
+		if err != nil {
+			return result, err
+		}
+		for i, f := range schema.Static {
+			result.Fields = append(result.Fields, newDecodedField(f, staticValues[i]))
+		}
+		for i, f := range schema.Dynamic {
+			result.Fields = append(result.Fields, newDecodedField(f, dynamicValues[i]))
+		}
+	case "setStaticField":
+		field, err := decodeStaticField(schema, int(args[2].(uint8)), args[3].([]byte))
+		if err != nil {
+			return result, err
+		}
+		result.Fields = append(result.Fields, field)
+	case "setDynamicField", "pushToDynamicField":
+		field, err := decodeDynamicField(schema, int(args[2].(uint8)), args[3].([]byte))
+		if err != nil {
+			return result, err
+		}
+		result.Fields = append(result.Fields, field)
+	case "setField":
+		index := int(args[2].(uint8))
+		var field DecodedField
+		if index < len(schema.Static) {
+			field, err = decodeStaticField(schema, index, args[3].([]byte))
+		} else {
+			field, err = decodeDynamicField(schema, index-len(schema.Static), args[3].([]byte))
+		}
+		if err != nil {
+			return result, err
+		}
+		result.Fields = append(result.Fields, field)
+	case "deleteRecord":
+	case "spliceStaticData":
+		start := args[2].(*big.Int)
+		data := args[3].([]byte)
+		// decode as a field when the splice covers exactly one static field
+		for i, f := range schema.Static {
+			if int64(schema.StaticFieldOffset(i)) == start.Int64() && StaticByteLength(f.Type) == len(data) {
+				result.Fields = append(result.Fields, newDecodedField(f, DecodeStaticValue(f.Type, data)))
+				return result, nil
+			}
+		}
+		result.Args = map[string]interface{}{"start": start, "data": hexutil.Bytes(data)}
+	case "spliceDynamicData":
+		field, err := decodeDynamicField(schema, int(args[2].(uint8)), args[5].([]byte))
+		if err != nil {
+			return result, err
+		}
+		result.Fields = append(result.Fields, field)
+		result.Args = map[string]interface{}{"startWithinField": args[3], "deleteCount": args[4]}
+	case "popFromDynamicField":
+		index := int(args[2].(uint8))
+		if index >= len(schema.Dynamic) {
+			return result, fmt.Errorf("table %s: dynamic field index %d out of range", schema.Name, index)
+		}
+		result.Args = map[string]interface{}{"field": schema.Dynamic[index].Name, "byteLengthToPop": args[3]}
+	default:
+		return result, fmt.Errorf("unsupported method %s", method.Name)
+	}
+	return result, nil
+}
+
+func decodeStaticField(schema TableSchema, index int, data []byte) (DecodedField, error) {
+	if index >= len(schema.Static) {
+		return DecodedField{}, fmt.Errorf("table %s: static field index %d out of range", schema.Name, index)
+	}
+	f := schema.Static[index]
+	if len(data) != StaticByteLength(f.Type) {
+		return DecodedField{}, fmt.Errorf("table %s: field %s length %d", schema.Name, f.Name, len(data))
+	}
+	return newDecodedField(f, DecodeStaticValue(f.Type, data)), nil
+}
+
+func decodeDynamicField(schema TableSchema, index int, data []byte) (DecodedField, error) {
+	if index >= len(schema.Dynamic) {
+		return DecodedField{}, fmt.Errorf("table %s: dynamic field index %d out of range", schema.Name, index)
+	}
+	f := schema.Dynamic[index]
+	value, err := DecodeDynamicValue(f.Type, data)
+	if err != nil {
+		return DecodedField{}, err
+	}
+	return newDecodedField(f, value), nil
+}
+
+func decodeKeyTuple(schema TableSchema, keyTuple [][32]byte) ([]DecodedField, error) {
+	if len(keyTuple) != len(schema.Key) {
+		return nil, fmt.Errorf("table %s: got %d keys, schema has %d", schema.Name, len(keyTuple), len(schema.Key))
+	}
+	result := make([]DecodedField, 0, len(keyTuple))
+	for i, f := range schema.Key {
+		size := StaticByteLength(f.Type)
+		result = append(result, newDecodedField(f, DecodeStaticValue(f.Type, keyTuple[i][32-size:])))
+	}
+	return result, nil
+}
+
+func newDecodedField(f FieldSchema, value interface{}) DecodedField {
+	df := DecodedField{Name: f.Name, Type: f.Type, Value: value}
+	if f.Enum == "" {
+		return df
+	}
+	df.Type = f.Enum
+	if v, ok := value.(*big.Int); ok && v.IsInt64() && int(v.Int64()) < len(Enums[f.Enum]) {
+		df.Enum = Enums[f.Enum][v.Int64()]
+	}
+	return df
+}
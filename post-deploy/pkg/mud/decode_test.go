@@ -0,0 +1,28 @@
+package mud
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCalldata(t *testing.T) {
+	mt := NewMudTable("TileInfo3", "app", "")
+	keyTuple := [][32]byte{
+		[32]byte(math.U256Bytes(big.NewInt(-3))),
+		[32]byte(math.U256Bytes(big.NewInt(4))),
+	}
+	callData, err := mt.SetStaticFieldRawCalldata(keyTuple, 2, []byte{2})
+	require.NoError(t, err)
+	decoded, err := DecodeCalldata(callData)
+	require.NoError(t, err)
+	require.Equal(t, "setStaticField app:TileInfo3 (x=-3, y=4) zoneType=Red(2)", decoded.String())
+
+	callData, err = mt.SetDynamicFieldRawCalldata(keyTuple, 0, math.U256Bytes(big.NewInt(7)))
+	require.NoError(t, err)
+	decoded, err = DecodeCalldata(callData)
+	require.NoError(t, err)
+	require.Equal(t, "setDynamicField app:TileInfo3 (x=-3, y=4) itemIds=[7]", decoded.String())
+}
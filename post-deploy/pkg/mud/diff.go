@@ -0,0 +1,143 @@
+package mud
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeChanged ChangeKind = "changed"
+	ChangeRemoved ChangeKind = "removed"
+)
+
+// RecordChange is a record that differs between two snapshots and the calls that patch it
+type RecordChange struct {
+	Kind     ChangeKind
+	Table    string
+	Key      []DecodedField
+	Fields   []string // changed fields of a changed record
+	CallData [][]byte
+}
+
+// String returns a single line human-readable form of the change
+func (rc RecordChange) String() string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %s (", rc.Kind, rc.Table)
+	for i, k := range rc.Key {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(k.String())
+	}
+	b.WriteString(")")
+	if len(rc.Fields) > 0 {
+		fmt.Fprintf(&b, " %v", rc.Fields)
+	}
+	return b.String()
+}
+
+// DiffCalldata compares the records written by two lists of calls.
+// Added records and records with several changed fields get a setRecord,
+// a single changed field gets a setStaticField or setDynamicField and removed records a deleteRecord.
+// Added and changed records come in the order of newCallData, then the removed ones.
+func DiffCalldata(oldCallData, newCallData [][]byte) ([]RecordChange, error) {
+	oldRecords, err := applyAll(oldCallData)
+	if err != nil {
+		return nil, fmt.Errorf("old snapshot: %w", err)
+	}
+	newRecords, err := applyAll(newCallData)
+	if err != nil {
+		return nil, fmt.Errorf("new snapshot: %w", err)
+	}
+	return DiffRecords(oldRecords, newRecords)
+}
+
+// DiffRecords is DiffCalldata on already folded records
+func DiffRecords(oldRecords, newRecords *RecordSet) ([]RecordChange, error) {
+	var changes []RecordChange
+	for _, r := range newRecords.Records() {
+		oldRecord, ok := oldRecords.Get(r.Key())
+		if !ok {
+			change, err := newRecordChange(ChangeAdded, r, nil)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+			continue
+		}
+		fieldIndexes := changedFields(oldRecord, r)
+		if len(fieldIndexes) == 0 {
+			continue
+		}
+		change, err := newRecordChange(ChangeChanged, r, fieldIndexes)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	for _, r := range oldRecords.Records() {
+		if _, ok := newRecords.Get(r.Key()); ok {
+			continue
+		}
+		change, err := newRecordChange(ChangeRemoved, r, nil)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func applyAll(callData [][]byte) (*RecordSet, error) {
+	records := NewRecordSet()
+	for i, data := range callData {
+		if err := records.Apply(data); err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+	}
+	return records, nil
+}
+
+// changedFields returns the indexes of the fields that differ, static fields first
+func changedFields(oldRecord, newRecord *Record) []int {
+	var result []int
+	for i := range newRecord.StaticFields {
+		if !bytes.Equal(oldRecord.StaticFields[i], newRecord.StaticFields[i]) {
+			result = append(result, i)
+		}
+	}
+	for i := range newRecord.DynamicFields {
+		if !bytes.Equal(oldRecord.DynamicFields[i], newRecord.DynamicFields[i]) {
+			result = append(result, len(newRecord.StaticFields)+i)
+		}
+	}
+	return result
+}
+
+func newRecordChange(kind ChangeKind, r *Record, fieldIndexes []int) (RecordChange, error) {
+	key, err := decodeKeyTuple(r.Schema, r.KeyTuple)
+	if err != nil {
+		return RecordChange{}, err
+	}
+	change := RecordChange{Kind: kind, Table: r.Schema.Name, Key: key}
+	for _, index := range fieldIndexes {
+		change.Fields = append(change.Fields, r.FieldName(index))
+	}
+	var callData []byte
+	switch {
+	case kind == ChangeRemoved:
+		callData, err = r.DeleteRecordCalldata()
+	case len(fieldIndexes) == 1:
+		callData, err = r.SetFieldCalldata(fieldIndexes[0])
+	default:
+		callData, err = r.SetRecordCalldata()
+	}
+	if err != nil {
+		return RecordChange{}, err
+	}
+	change.CallData = [][]byte{callData}
+	return change, nil
+}
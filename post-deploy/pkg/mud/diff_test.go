@@ -0,0 +1,84 @@
+package mud
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffCalldata(t *testing.T) {
+	mt := NewMudTable("TileInfo3", "app", "")
+	tileKey := func(x, y int64) [][32]byte {
+		return [][32]byte{
+			[32]byte(math.U256Bytes(big.NewInt(x))),
+			[32]byte(math.U256Bytes(big.NewInt(y))),
+		}
+	}
+	// tile builds a TileInfo3 record with one item id
+	tile := func(x, y int64, zoneType byte, itemId int64) []byte {
+		staticData := make([]byte, mt.Schema.StaticLength())
+		staticData[2] = zoneType
+		encodedLength, err := EncodeLengths([]int{32, 0, 0})
+		require.NoError(t, err)
+		callData, err := mt.SetRecordRawCalldata(tileKey(x, y), staticData, encodedLength, math.U256Bytes(big.NewInt(itemId)))
+		require.NoError(t, err)
+		return callData
+	}
+	zoneTypeOf := func(x, y int64, zoneType byte) []byte {
+		callData, err := mt.SetStaticFieldRawCalldata(tileKey(x, y), 2, []byte{zoneType})
+		require.NoError(t, err)
+		return callData
+	}
+	decode := func(change RecordChange) string {
+		require.Len(t, change.CallData, 1)
+		decoded, err := DecodeCalldata(change.CallData[0])
+		require.NoError(t, err)
+		return decoded.String()
+	}
+
+	oldCallData := [][]byte{
+		tile(0, 0, 1, 7),
+		tile(0, 1, 1, 7),
+		tile(0, 2, 1, 7),
+		tile(0, 3, 1, 7),
+		zoneTypeOf(0, 3, 2), // later calls are folded into the record
+	}
+	newCallData := [][]byte{
+		tile(0, 0, 1, 7), // unchanged
+		tile(0, 1, 2, 7), // one static field
+		tile(0, 2, 2, 8), // two fields
+		tile(0, 3, 2, 7), // same as the folded record
+		tile(0, 4, 1, 7), // added
+	}
+	changes, err := DiffCalldata(oldCallData, newCallData)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+
+	require.Equal(t, "changed TileInfo3 (x=0, y=1) [zoneType]", changes[0].String())
+	require.Equal(t, "setStaticField app:TileInfo3 (x=0, y=1) zoneType=Red(2)", decode(changes[0]))
+
+	require.Equal(t, "changed TileInfo3 (x=0, y=2) [zoneType itemIds]", changes[1].String())
+	require.Equal(t, "setRecord", decode(changes[1])[:len("setRecord")])
+
+	require.Equal(t, "added TileInfo3 (x=0, y=4)", changes[2].String())
+	require.Equal(t, tile(0, 4, 1, 7), changes[2].CallData[0])
+
+	// removed records come last
+	changes, err = DiffCalldata(newCallData, oldCallData[:2])
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+	require.Equal(t, "changed TileInfo3 (x=0, y=1) [zoneType]", changes[0].String())
+	for i, y := range []int{2, 3, 4} {
+		require.Equal(t, ChangeRemoved, changes[i+1].Kind)
+		require.Equal(t, fmt.Sprintf("deleteRecord app:TileInfo3 (x=0, y=%d)", y), decode(changes[i+1]))
+	}
+
+	// a dynamic field edit
+	changes, err = DiffCalldata(oldCallData[:1], [][]byte{tile(0, 0, 1, 9)})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, "setDynamicField app:TileInfo3 (x=0, y=0) itemIds=[9]", decode(changes[0]))
+}
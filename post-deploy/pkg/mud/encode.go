@@ -0,0 +1,182 @@
+package mud
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	bigIntType  = reflect.TypeOf(&big.Int{})
+	addressType = reflect.TypeOf(common.Address{})
+	bytesType   = reflect.TypeOf([]byte{})
+)
+
+// EncodeStaticValue packs one static value the way MUD stores it: big-endian with the byte
+// length of the solidity type, two's complement for signed integers and left aligned bytesN.
+// Integers can be any go integer type or *big.Int and are checked against the type range.
+func EncodeStaticValue(solType string, value interface{}) ([]byte, error) {
+	size := StaticByteLength(solType)
+	if size == 0 || IsDynamicType(solType) {
+		return nil, fmt.Errorf("%s is not a static type", solType)
+	}
+	switch {
+	case solType == "bool":
+		v, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("cannot pack %T as %s", value, solType)
+		}
+		if v {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case solType == "address":
+		v, ok := value.(common.Address)
+		if !ok {
+			return nil, fmt.Errorf("cannot pack %T as %s", value, solType)
+		}
+		return v.Bytes(), nil
+	case strings.HasPrefix(solType, "bytes"):
+		data, ok := bytesValue(value)
+		if !ok {
+			return nil, fmt.Errorf("cannot pack %T as %s", value, solType)
+		}
+		if len(data) != size {
+			return nil, fmt.Errorf("cannot pack %d bytes as %s", len(data), solType)
+		}
+		return data, nil
+	}
+	v, ok := integerValue(value)
+	if !ok {
+		return nil, fmt.Errorf("cannot pack %T as %s", value, solType)
+	}
+	return encodeInteger(solType, size, v)
+}
+
+// EncodeDynamicValue packs one dynamic value: string and bytes as is, T[] and T[N] as the
+// packed elements without padding or length prefix
+func EncodeDynamicValue(solType string, value interface{}) ([]byte, error) {
+	switch solType {
+	case "string":
+		switch v := value.(type) {
+		case string:
+			return []byte(v), nil
+		case []byte:
+			return v, nil
+		}
+		return nil, fmt.Errorf("cannot pack %T as %s", value, solType)
+	case "bytes":
+		v, ok := value.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("cannot pack %T as %s", value, solType)
+		}
+		return v, nil
+	}
+	elem, length, ok := ArrayElementType(solType)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a dynamic type", solType)
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cannot pack %T as %s", value, solType)
+	}
+	if length > 0 && rv.Len() != length {
+		return nil, fmt.Errorf("cannot pack %d elements as %s", rv.Len(), solType)
+	}
+	var buffer bytes.Buffer
+	for i := 0; i < rv.Len(); i++ {
+		data, err := EncodeStaticValue(elem, rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		buffer.Write(data)
+	}
+	return buffer.Bytes(), nil
+}
+
+// EncodeValue packs a static or dynamic value of the given solidity type
+func EncodeValue(solType string, value interface{}) ([]byte, error) {
+	if IsDynamicType(solType) {
+		return EncodeDynamicValue(solType, value)
+	}
+	return EncodeStaticValue(solType, value)
+}
+
+// EncodeKey returns the bytes32 key tuple entry of a static value:
+// integers are sign extended to 32 bytes, bytesN stay left aligned
+func EncodeKey(solType string, value interface{}) ([32]byte, error) {
+	var key [32]byte
+	data, err := EncodeStaticValue(solType, value)
+	if err != nil {
+		return key, err
+	}
+	if strings.HasPrefix(solType, "bytes") {
+		copy(key[:], data)
+		return key, nil
+	}
+	if strings.HasPrefix(solType, "int") && data[0]&0x80 != 0 {
+		for i := range key {
+			key[i] = 0xff
+		}
+	}
+	copy(key[32-len(data):], data)
+	return key, nil
+}
+
+func bytesValue(value interface{}) ([]byte, bool) {
+	if v, ok := value.([]byte); ok {
+		return v, true
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Array || rv.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+	data := make([]byte, rv.Len())
+	reflect.Copy(reflect.ValueOf(data), rv)
+	return data, true
+}
+
+// integerValue converts any go integer kind, including named enum types, to *big.Int
+func integerValue(value interface{}) (*big.Int, bool) {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return nil, false
+	}
+	if rv.Type() == bigIntType {
+		if rv.IsNil() {
+			return nil, false
+		}
+		return value.(*big.Int), true
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), true
+	}
+	return nil, false
+}
+
+func encodeInteger(solType string, size int, v *big.Int) ([]byte, error) {
+	bits := uint(size * BYTE_TO_BITS)
+	limit := new(big.Int).Lsh(big.NewInt(1), bits)
+	value := new(big.Int).Set(v)
+	if strings.HasPrefix(solType, "uint") {
+		if value.Sign() < 0 || value.Cmp(limit) >= 0 {
+			return nil, fmt.Errorf("%s out of %s range", v, solType)
+		}
+	} else {
+		half := new(big.Int).Rsh(limit, 1)
+		if value.Cmp(new(big.Int).Neg(half)) < 0 || value.Cmp(half) >= 0 {
+			return nil, fmt.Errorf("%s out of %s range", v, solType)
+		}
+		if value.Sign() < 0 {
+			value.Add(value, limit)
+		}
+	}
+	return value.FillBytes(make([]byte, size)), nil
+}
@@ -0,0 +1,248 @@
+package mud
+
+import (
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+// staticTestTypes are the static types used by the schemas plus the widths around them
+var staticTestTypes = []string{
+	"bool", "address", "bytes32", "bytes4",
+	"uint8", "uint16", "uint24", "uint32", "uint64", "uint128", "uint256",
+	"int8", "int16", "int32", "int64", "int128", "int256",
+}
+
+// randomInteger returns a random value in the range of the integer type
+func randomInteger(r *rand.Rand, solType string) *big.Int {
+	bits := uint(StaticByteLength(solType) * BYTE_TO_BITS)
+	limit := new(big.Int).Lsh(big.NewInt(1), bits)
+	v := new(big.Int).Rand(r, limit)
+	if strings.HasPrefix(solType, "int") {
+		v.Sub(v, new(big.Int).Rsh(limit, 1))
+	}
+	return v
+}
+
+// abiValue converts v to the go type expected by the abi package for solType
+func abiValue(solType string, v *big.Int) interface{} {
+	signed := strings.HasPrefix(solType, "int")
+	switch StaticByteLength(solType) {
+	case 1:
+		if signed {
+			return int8(v.Int64())
+		}
+		return uint8(v.Uint64())
+	case 2:
+		if signed {
+			return int16(v.Int64())
+		}
+		return uint16(v.Uint64())
+	case 4:
+		if signed {
+			return int32(v.Int64())
+		}
+		return uint32(v.Uint64())
+	case 8:
+		if signed {
+			return v.Int64()
+		}
+		return v.Uint64()
+	}
+	return v
+}
+
+// randomStaticValue returns a value to encode and the same value in the abi package go type
+func randomStaticValue(r *rand.Rand, solType string) (interface{}, interface{}) {
+	switch {
+	case solType == "bool":
+		v := r.Intn(2) == 1
+		return v, v
+	case solType == "address":
+		var v common.Address
+		r.Read(v[:])
+		return v, v
+	case solType == "bytes32":
+		var v [32]byte
+		r.Read(v[:])
+		return v, v
+	case solType == "bytes4":
+		var v [4]byte
+		r.Read(v[:])
+		return v, v
+	}
+	v := randomInteger(r, solType)
+	return v, abiValue(solType, v)
+}
+
+// abiPackWord packs one value with the abi package, static values take exactly one word
+func abiPackWord(t *testing.T, solType string, value interface{}) []byte {
+	typ, err := abi.NewType(solType, "", nil)
+	require.NoError(t, err)
+	word, err := abi.Arguments{{Type: typ}}.Pack(value)
+	require.NoError(t, err)
+	return word
+}
+
+// packedFromWord cuts the packed encoding out of an abi word
+func packedFromWord(solType string, word []byte) []byte {
+	size := StaticByteLength(solType)
+	if strings.HasPrefix(solType, "bytes") {
+		return word[:size]
+	}
+	return word[32-size:]
+}
+
+func TestEncodeStaticValueMatchesABI(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, solType := range staticTestTypes {
+		for i := 0; i < 200; i++ {
+			value, abiV := randomStaticValue(r, solType)
+			packed, err := EncodeStaticValue(solType, value)
+			require.NoError(t, err, solType)
+			word := abiPackWord(t, solType, abiV)
+			require.Equal(t, packedFromWord(solType, word), packed, "%s %v", solType, value)
+
+			key, err := EncodeKey(solType, value)
+			require.NoError(t, err)
+			require.Equal(t, word, key[:], "%s %v", solType, value)
+
+			decoded := DecodeStaticValue(solType, packed)
+			switch v := value.(type) {
+			case *big.Int:
+				require.Equal(t, 0, v.Cmp(decoded.(*big.Int)), "%s %v", solType, value)
+			case [32]byte:
+				require.Equal(t, v[:], []byte(decoded.(hexutil.Bytes)))
+			case [4]byte:
+				require.Equal(t, v[:], []byte(decoded.(hexutil.Bytes)))
+			default:
+				require.Equal(t, value, decoded)
+			}
+		}
+	}
+}
+
+func TestEncodeStaticValueNativeTypes(t *testing.T) {
+	type zoneType uint8
+	tests := []struct {
+		solType string
+		value   interface{}
+		want    []byte
+	}{
+		{"uint8", zoneType(2), []byte{2}},
+		{"uint8", uint8(255), []byte{255}},
+		{"int8", int8(-1), []byte{0xff}},
+		{"int16", int16(-2), []byte{0xff, 0xfe}},
+		{"int32", int32(-3), []byte{0xff, 0xff, 0xff, 0xfd}},
+		{"uint32", 7, []byte{0, 0, 0, 7}},
+		{"int128", big.NewInt(-1), []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+	}
+	for _, tc := range tests {
+		packed, err := EncodeStaticValue(tc.solType, tc.value)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, packed, "%s %v", tc.solType, tc.value)
+	}
+
+	for _, tc := range []struct {
+		solType string
+		value   interface{}
+	}{
+		{"uint8", 256},
+		{"uint8", -1},
+		{"int8", 128},
+		{"int8", -129},
+		{"uint256", big.NewInt(-1)},
+		{"int256", new(big.Int).Lsh(big.NewInt(1), 255)},
+		{"uint128", new(big.Int).Lsh(big.NewInt(1), 128)},
+		{"bytes32", []byte{1}},
+		{"address", "0x00"},
+		{"bool", 1},
+		{"uint256[]", []uint8{1}},
+	} {
+		_, err := EncodeStaticValue(tc.solType, tc.value)
+		require.Error(t, err, "%s %v", tc.solType, tc.value)
+	}
+}
+
+func TestEncodeDynamicValueMatchesABI(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for _, solType := range []string{"uint256[2]", "uint32[2]", "int32[2]", "uint8[2]", "uint16[3]", "uint256[]", "uint32[]", "int32[]", "uint16[]", "uint8[]"} {
+		elem, length, ok := ArrayElementType(solType)
+		require.True(t, ok)
+		n := length
+		if n == 0 {
+			n = r.Intn(10)
+		}
+		values := make([]*big.Int, n)
+		for i := range values {
+			values[i] = randomInteger(r, elem)
+		}
+		packed, err := EncodeDynamicValue(solType, values)
+		require.NoError(t, err, solType)
+
+		// compare each element with the abi word of the same value
+		require.Len(t, packed, n*StaticByteLength(elem))
+		for i, v := range values {
+			word := abiPackWord(t, elem, abiValue(elem, v))
+			size := StaticByteLength(elem)
+			require.Equal(t, packedFromWord(elem, word), packed[i*size:(i+1)*size], "%s[%d]", solType, i)
+		}
+
+		decoded, err := DecodeDynamicValue(solType, packed)
+		require.NoError(t, err)
+		require.Len(t, decoded, n)
+		for i, v := range values {
+			require.Equal(t, 0, v.Cmp(decoded.([]interface{})[i].(*big.Int)))
+		}
+	}
+
+	_, err := EncodeDynamicValue("uint256[2]", []*big.Int{big.NewInt(1)})
+	require.Error(t, err)
+	_, err = EncodeDynamicValue("uint8[]", []int{256})
+	require.Error(t, err)
+	packed, err := EncodeDynamicValue("string", "abc")
+	require.NoError(t, err)
+	require.Equal(t, []byte("abc"), packed)
+}
+
+func FuzzEncodeInteger(f *testing.F) {
+	f.Add(uint8(0), []byte{0x01}, false)
+	f.Add(uint8(5), []byte{0x80}, true)
+	f.Add(uint8(10), []byte{0xff, 0xff}, true)
+	f.Add(uint8(15), make([]byte, 33), false)
+	integerTypes := make([]string, 0)
+	for _, solType := range staticTestTypes {
+		if strings.Contains(solType, "int") {
+			integerTypes = append(integerTypes, solType)
+		}
+	}
+	f.Fuzz(func(t *testing.T, typeIndex uint8, raw []byte, negative bool) {
+		solType := integerTypes[int(typeIndex)%len(integerTypes)]
+		v := new(big.Int).SetBytes(raw)
+		if negative {
+			v.Neg(v)
+		}
+		packed, err := EncodeStaticValue(solType, v)
+		bits := uint(StaticByteLength(solType) * BYTE_TO_BITS)
+		min, max := big.NewInt(0), new(big.Int).Lsh(big.NewInt(1), bits)
+		if strings.HasPrefix(solType, "int") {
+			max.Rsh(max, 1)
+			min.Neg(max)
+		}
+		inRange := v.Cmp(min) >= 0 && v.Cmp(max) < 0
+		if !inRange {
+			require.Error(t, err, "%s %s", solType, v)
+			return
+		}
+		require.NoError(t, err, "%s %s", solType, v)
+		word := abiPackWord(t, solType, abiValue(solType, v))
+		require.Equal(t, packedFromWord(solType, word), packed)
+		require.Equal(t, 0, v.Cmp(DecodeStaticValue(solType, packed).(*big.Int)))
+	})
+}
@@ -0,0 +1,271 @@
+// gen-schema reads mud.config.ts and the tables/*.ts files it imports
+// and writes the Go schema registry used by pkg/mud and pkg/table.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// field and table mirror mud.FieldSchema and mud.TableSchema.
+// They are duplicated so the generator still builds when schemas_gen.go is missing.
+type field struct {
+	Name string
+	Type string
+	Enum string
+}
+
+type table struct {
+	Name      string
+	Namespace string
+	Key       []field
+	Static    []field
+	Dynamic   []field
+}
+
+var (
+	lineCommentRe  = regexp.MustCompile(`//[^\n]*`)
+	blockCommentRe = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	namespaceRe    = regexp.MustCompile(`namespace\s*:\s*["'](\w+)["']`)
+	importRe       = regexp.MustCompile(`import\s+\w+\s+from\s+["'](\./tables/\w+)["']`)
+	enumRe         = regexp.MustCompile(`(\w+)\s*:\s*\[([^\]]*)\]`)
+	tableRe        = regexp.MustCompile(`(\w+)\s*:\s*\{\s*schema\s*:\s*\{([^}]*)\}\s*,?\s*key\s*:\s*\[([^\]]*)\]`)
+	columnRe       = regexp.MustCompile(`(\w+)\s*:\s*["']([^"']+)["']`)
+	quotedRe       = regexp.MustCompile(`["'](\w+)["']`)
+	staticTypeRe   = regexp.MustCompile(`^(bool|address|u?int(\d*)|bytes(\d+))$`)
+)
+
+func main() {
+	root := flag.String("root", ".", "path to the contract repo containing mud.config.ts")
+	out := flag.String("out", "schemas_gen.go", "path to the generated go file")
+	flag.Parse()
+
+	config, err := readSource(filepath.Join(*root, "mud.config.ts"))
+	if err != nil {
+		log.Fatalf("cannot read mud config: %v", err)
+	}
+	namespace := ""
+	if m := namespaceRe.FindStringSubmatch(config); m != nil {
+		namespace = m[1]
+	}
+	enums, err := parseEnums(config)
+	if err != nil {
+		log.Fatalf("cannot parse enums: %v", err)
+	}
+
+	sources := []string{config}
+	for _, m := range importRe.FindAllStringSubmatch(config, -1) {
+		src, err := readSource(filepath.Join(*root, m[1]+".ts"))
+		if err != nil {
+			log.Fatalf("cannot read table file: %v", err)
+		}
+		sources = append(sources, src)
+	}
+
+	tables := make(map[string]table)
+	for _, src := range sources {
+		for _, m := range tableRe.FindAllStringSubmatch(src, -1) {
+			schema, err := parseTable(m[1], namespace, m[2], m[3], enums)
+			if err != nil {
+				log.Fatalf("cannot parse table %s: %v", m[1], err)
+			}
+			if _, ok := tables[schema.Name]; ok {
+				log.Fatalf("duplicate table %s", schema.Name)
+			}
+			tables[schema.Name] = schema
+		}
+	}
+
+	code, err := render(enums, tables)
+	if err != nil {
+		log.Fatalf("cannot render schemas: %v", err)
+	}
+	if err := os.WriteFile(*out, code, 0644); err != nil {
+		log.Fatalf("cannot write %s: %v", *out, err)
+	}
+}
+
+func readSource(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	src := blockCommentRe.ReplaceAllString(string(raw), "")
+	return lineCommentRe.ReplaceAllString(src, ""), nil
+}
+
+func parseEnums(config string) (map[string][]string, error) {
+	start := strings.Index(config, "enums:")
+	if start < 0 {
+		return map[string][]string{}, nil
+	}
+	body, err := braceBody(config[start:])
+	if err != nil {
+		return nil, err
+	}
+	enums := make(map[string][]string)
+	for _, m := range enumRe.FindAllStringSubmatch(body, -1) {
+		var values []string
+		for _, v := range quotedRe.FindAllStringSubmatch(m[2], -1) {
+			values = append(values, v[1])
+		}
+		enums[m[1]] = values
+	}
+	return enums, nil
+}
+
+// braceBody returns the content between the first '{' and its matching '}'
+func braceBody(s string) (string, error) {
+	open := strings.Index(s, "{")
+	if open < 0 {
+		return "", fmt.Errorf("missing '{'")
+	}
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return s[open+1 : i], nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unbalanced '{'")
+}
+
+func parseTable(name, namespace, rawSchema, rawKey string, enums map[string][]string) (table, error) {
+	keys := make(map[string]bool)
+	for _, k := range quotedRe.FindAllStringSubmatch(rawKey, -1) {
+		keys[k[1]] = true
+	}
+	schema := table{Name: name, Namespace: namespace}
+	for _, m := range columnRe.FindAllStringSubmatch(rawSchema, -1) {
+		f := field{Name: m[1], Type: m[2]}
+		if _, ok := enums[f.Type]; ok {
+			f.Enum = f.Type
+			f.Type = "uint8"
+		}
+		dynamic, err := isDynamic(f.Type)
+		if err != nil {
+			return schema, fmt.Errorf("column %s: %w", f.Name, err)
+		}
+		switch {
+		case keys[f.Name]:
+			if dynamic {
+				return schema, fmt.Errorf("key %s must be a static type", f.Name)
+			}
+			schema.Key = append(schema.Key, f)
+			delete(keys, f.Name)
+		case dynamic:
+			schema.Dynamic = append(schema.Dynamic, f)
+		default:
+			schema.Static = append(schema.Static, f)
+		}
+	}
+	if len(keys) != 0 {
+		return schema, fmt.Errorf("key columns not in schema: %v", keys)
+	}
+	return schema, nil
+}
+
+// isDynamic reports whether a column is stored in the dynamic part of a record.
+// MUD stores both T[] and T[N] arrays as dynamic fields.
+func isDynamic(solType string) (bool, error) {
+	if solType == "string" || solType == "bytes" {
+		return true, nil
+	}
+	elem := solType
+	dynamic := false
+	if open := strings.LastIndex(solType, "["); open > 0 && strings.HasSuffix(solType, "]") {
+		if n := solType[open+1 : len(solType)-1]; n != "" {
+			if _, err := strconv.Atoi(n); err != nil {
+				return false, fmt.Errorf("invalid array length in %s", solType)
+			}
+		}
+		elem = solType[:open]
+		dynamic = true
+	}
+	m := staticTypeRe.FindStringSubmatch(elem)
+	if m == nil {
+		return false, fmt.Errorf("unknown type %s", solType)
+	}
+	if bits := m[2]; bits != "" {
+		if n, _ := strconv.Atoi(bits); n == 0 || n > 256 || n%8 != 0 {
+			return false, fmt.Errorf("invalid integer width in %s", solType)
+		}
+	}
+	if size := m[3]; size != "" {
+		if n, _ := strconv.Atoi(size); n == 0 || n > 32 {
+			return false, fmt.Errorf("invalid bytes size in %s", solType)
+		}
+	}
+	return dynamic, nil
+}
+
+func render(enums map[string][]string, tables map[string]table) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen-schema from mud.config.ts; DO NOT EDIT.\n\n")
+	b.WriteString("package mud\n\n")
+
+	b.WriteString("// Enums contains the enum values declared in mud.config.ts\n")
+	b.WriteString("var Enums = map[string][]string{\n")
+	for _, name := range sortedKeys(enums) {
+		fmt.Fprintf(&b, "%q: {", name)
+		for i, v := range enums[name] {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%q", v)
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("// Tables contains the schema of every table declared in mud.config.ts\n")
+	b.WriteString("var Tables = map[string]TableSchema{\n")
+	for _, name := range sortedKeys(tables) {
+		t := tables[name]
+		fmt.Fprintf(&b, "%q: {\nName: %q,\nNamespace: %q,\n", t.Name, t.Name, t.Namespace)
+		writeFields(&b, "Key", t.Key)
+		writeFields(&b, "Static", t.Static)
+		writeFields(&b, "Dynamic", t.Dynamic)
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
+
+func writeFields(b *bytes.Buffer, name string, fields []field) {
+	if len(fields) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "%s: []FieldSchema{\n", name)
+	for _, f := range fields {
+		if f.Enum != "" {
+			fmt.Fprintf(b, "{Name: %q, Type: %q, Enum: %q},\n", f.Name, f.Type, f.Enum)
+		} else {
+			fmt.Fprintf(b, "{Name: %q, Type: %q},\n", f.Name, f.Type)
+		}
+	}
+	b.WriteString("},\n")
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,30 +1,23 @@
 package mud
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
 )
 
-type ResourceId [32]byte
 type FieldLayout [32]byte
 type PackedCounter [32]byte
 
-const RESOURCE_TABLE = "tb"
-
-func getTableId(tableName, nameSpace string) ResourceId {
-	data := []byte(RESOURCE_TABLE)
-	data = append(data, make([]byte, 2-len(RESOURCE_TABLE))...) // Padding to ensure "tb" is bytes2
-	data = append(data, []byte(nameSpace)...)                   // Adding nameSpace directly
-	data = append(data, make([]byte, 14-len(nameSpace))...)     // Padding to ensure nameSpace fits into bytes14
-	data = append(data, []byte(tableName)...)
-	data = append(data, make([]byte, 32-len(data))...) // Ensure total length is 32 bytes
-
-	var rawTableId common.Hash = common.BytesToHash(data)
-	var tableId [32]byte
-	copy(tableId[:], rawTableId[:])
-	return tableId
+// getTableId returns the onchain table id, names longer than 16 bytes are truncated
+// the same way mud.config.ts resolves table names
+func getTableId(tableName, nameSpace string) (ResourceId, error) {
+	if len(tableName) > RESOURCE_NAME_BYTES {
+		tableName = tableName[:RESOURCE_NAME_BYTES]
+	}
+	return NewTableId(nameSpace, tableName)
 }
 
 func getFieldLayout(rawHex string) FieldLayout {
@@ -32,25 +25,91 @@ func getFieldLayout(rawHex string) FieldLayout {
 	return FieldLayout(hash)
 }
 
+const (
+	MAX_TOTAL_FIELDS   = 28
+	MAX_DYNAMIC_FIELDS = 5
+)
+
+// EncodeFieldLayout builds the MUD FieldLayout of a table schema:
+// bytes 0-1 total static length, byte 2 number of static fields, byte 3 number of dynamic fields,
+// then one byte per static field length
+func EncodeFieldLayout(schema TableSchema) (FieldLayout, error) {
+	var fl FieldLayout
+	if len(schema.Static)+len(schema.Dynamic) > MAX_TOTAL_FIELDS {
+		return fl, fmt.Errorf("table %s: more than %d fields", schema.Name, MAX_TOTAL_FIELDS)
+	}
+	if len(schema.Dynamic) > MAX_DYNAMIC_FIELDS {
+		return fl, fmt.Errorf("table %s: more than %d dynamic fields", schema.Name, MAX_DYNAMIC_FIELDS)
+	}
+	total := 0
+	for i, f := range schema.Static {
+		size := StaticByteLength(f.Type)
+		if size == 0 || size > 32 {
+			return fl, fmt.Errorf("table %s: invalid static field %s of type %s", schema.Name, f.Name, f.Type)
+		}
+		fl[4+i] = byte(size)
+		total += size
+	}
+	fl[0] = byte(total >> BYTE_TO_BITS)
+	fl[1] = byte(total)
+	fl[2] = byte(len(schema.Static))
+	fl[3] = byte(len(schema.Dynamic))
+	return fl, nil
+}
+
 const (
 	BYTE_TO_BITS = 8
 	ACC_BITS     = 7 * BYTE_TO_BITS
 	VAL_BITS     = 5 * BYTE_TO_BITS
 )
 
-func EncodeLengths(lengths []int) PackedCounter {
-	var packedCounterInt int
-	for _, l := range lengths {
-		packedCounterInt += l
+// EncodeLengths builds the PackedCounter of the dynamic field byte lengths:
+// the total in the lowest 7 bytes and each length in the next 5 bytes slots
+func EncodeLengths(lengths []int) (PackedCounter, error) {
+	if len(lengths) > MAX_DYNAMIC_FIELDS {
+		return PackedCounter{}, fmt.Errorf("%d dynamic lengths, max is %d", len(lengths), MAX_DYNAMIC_FIELDS)
 	}
-
-	packedCounter := big.NewInt(int64(packedCounterInt))
-
+	packedCounter := new(big.Int)
+	total := new(big.Int)
 	for index, value := range lengths {
-		shiftAmount := uint(ACC_BITS + VAL_BITS*index)
-		shiftedValue := new(big.Int).Lsh(big.NewInt(int64(value)), shiftAmount)
-		packedCounter.Or(packedCounter, shiftedValue)
+		length := big.NewInt(int64(value))
+		if length.Sign() < 0 || length.BitLen() > VAL_BITS {
+			return PackedCounter{}, fmt.Errorf("dynamic length %d at index %d does not fit in %d bits", value, index, VAL_BITS)
+		}
+		total.Add(total, length)
+		packedCounter.Or(packedCounter, new(big.Int).Lsh(length, uint(ACC_BITS+VAL_BITS*index)))
+	}
+	if total.BitLen() > ACC_BITS {
+		return PackedCounter{}, fmt.Errorf("total dynamic length %s does not fit in %d bits", total, ACC_BITS)
 	}
+	packedCounter.Or(packedCounter, total)
+	return PackedCounter(math.U256Bytes(packedCounter)), nil
+}
 
-	return PackedCounter(math.U256Bytes(packedCounter))
+// DecodeLengths returns the byte lengths of the first numFields dynamic fields,
+// it fails if the counter has lengths past numFields or the total is not their sum
+func DecodeLengths(packedCounter PackedCounter, numFields int) ([]int, error) {
+	if numFields > MAX_DYNAMIC_FIELDS {
+		return nil, fmt.Errorf("%d dynamic fields, max is %d", numFields, MAX_DYNAMIC_FIELDS)
+	}
+	value := new(big.Int).SetBytes(packedCounter[:])
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), VAL_BITS), big.NewInt(1))
+	total := new(big.Int).And(value, new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), ACC_BITS), big.NewInt(1)))
+	lengths := make([]int, 0, numFields)
+	sum := new(big.Int)
+	for index := 0; index < MAX_DYNAMIC_FIELDS; index++ {
+		length := new(big.Int).And(new(big.Int).Rsh(value, uint(ACC_BITS+VAL_BITS*index)), mask)
+		if index >= numFields {
+			if length.Sign() != 0 {
+				return nil, fmt.Errorf("length %s at index %d past %d dynamic fields", length, index, numFields)
+			}
+			continue
+		}
+		sum.Add(sum, length)
+		lengths = append(lengths, int(length.Int64()))
+	}
+	if sum.Cmp(total) != 0 {
+		return nil, fmt.Errorf("total dynamic length %s, sum of lengths %s", total, sum)
+	}
+	return lengths, nil
 }
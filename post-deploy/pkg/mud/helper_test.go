@@ -1,16 +1,72 @@
 package mud
 
 import (
-	"fmt"
 	"testing"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/require"
 )
 
 func TestGetTableId(t *testing.T) {
-	tableId := getTableId("", "MapConfig")
-	fmt.Println(common.Bytes2Hex(tableId[:]))
+	tableId, err := getTableId("MapConfig", "")
+	require.NoError(t, err)
 	require.Equal(t, "0x746200000000000000000000000000004d6170436f6e66696700000000000000", hexutil.Encode(tableId[:]))
+
+	// long names are truncated like mud.config.ts does
+	tableId, err = getTableId("CharOtherItemStorage", "app")
+	require.NoError(t, err)
+	require.Equal(t, "tb:app:CharOtherItemSto", tableId.String())
+}
+
+func TestEncodeLengths(t *testing.T) {
+	packedCounter, err := EncodeLengths([]int{32, 4, 0})
+	require.NoError(t, err)
+	// total 36 in the low 7 bytes, then 32, 4 and 0 in 5 bytes slots
+	require.Equal(t, "0x0000000000000000000000000000000000000004000000002000000000000024", hexutil.Encode(packedCounter[:]))
+	lengths, err := DecodeLengths(packedCounter, 3)
+	require.NoError(t, err)
+	require.Equal(t, []int{32, 4, 0}, lengths)
+
+	// a counter with more lengths than the table has dynamic fields is rejected
+	_, err = DecodeLengths(packedCounter, 1)
+	require.Error(t, err)
+	// the total must be the sum of the lengths
+	packedCounter[31]++
+	_, err = DecodeLengths(packedCounter, 3)
+	require.Error(t, err)
+
+	maxLength := 1<<VAL_BITS - 1
+	_, err = EncodeLengths([]int{maxLength})
+	require.NoError(t, err)
+	_, err = EncodeLengths([]int{maxLength + 1})
+	require.Error(t, err)
+	_, err = EncodeLengths([]int{-1})
+	require.Error(t, err)
+	_, err = EncodeLengths([]int{1, 2, 3, 4, 5, 6})
+	require.Error(t, err)
+	packedCounter, err = EncodeLengths([]int{maxLength, maxLength, maxLength, maxLength, maxLength})
+	require.NoError(t, err)
+	lengths, err = DecodeLengths(packedCounter, MAX_DYNAMIC_FIELDS)
+	require.NoError(t, err)
+	require.Equal(t, []int{maxLength, maxLength, maxLength, maxLength, maxLength}, lengths)
+	_, err = DecodeLengths(PackedCounter{}, MAX_DYNAMIC_FIELDS+1)
+	require.Error(t, err)
+	require.Error(t, err)
+}
+
+func TestSetRecordChecksEncodedLengths(t *testing.T) {
+	mt := NewMudTable("SkillV2", "app", "")
+	keyTuple := [][32]byte{{31: 1}}
+	staticData := make([]byte, mt.Schema.StaticLength())
+	// name "ab" and 1 byte in each uint8[] field
+	packedCounter, err := EncodeLengths([]int{2, 1, 1})
+	require.NoError(t, err)
+	_, err = mt.SetRecordRawCalldata(keyTuple, staticData, packedCounter, []byte("ab\x01\x02"))
+	require.NoError(t, err)
+	_, err = mt.SetRecordRawCalldata(keyTuple, staticData, packedCounter, []byte("ab\x01\x02\x03"))
+	require.Error(t, err)
+	packedCounter, err = EncodeLengths([]int{2, 1, 1, 1})
+	require.NoError(t, err)
+	_, err = mt.SetRecordRawCalldata(keyTuple, staticData, packedCounter, []byte("ab\x01\x02\x03"))
+	require.Error(t, err)
 }
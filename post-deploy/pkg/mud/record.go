@@ -0,0 +1,189 @@
+package mud
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+)
+
+// Record is the state of a table record after the World calls applied to it,
+// fields are kept encoded so that records can be compared field by field
+type Record struct {
+	Schema        TableSchema
+	TableId       ResourceId
+	KeyTuple      [][32]byte
+	StaticFields  [][]byte
+	DynamicFields [][]byte
+}
+
+// RecordKey identifies a record by its table and key tuple
+func RecordKey(tableId ResourceId, keyTuple [][32]byte) string {
+	var b bytes.Buffer
+	b.Write(tableId[:])
+	for _, key := range keyTuple {
+		b.Write(key[:])
+	}
+	return hex.EncodeToString(b.Bytes())
+}
+
+// Key returns the RecordKey of the record
+func (r *Record) Key() string {
+	return RecordKey(r.TableId, r.KeyTuple)
+}
+
+// FieldName returns the name of a field, static fields first then dynamic fields like setField
+func (r *Record) FieldName(fieldIndex int) string {
+	if fieldIndex < len(r.Schema.Static) {
+		return r.Schema.Static[fieldIndex].Name
+	}
+	return r.Schema.Dynamic[fieldIndex-len(r.Schema.Static)].Name
+}
+
+// SetRecordCalldata returns the setRecord calldata writing the whole record
+func (r *Record) SetRecordCalldata() ([]byte, error) {
+	lengths := make([]int, 0, len(r.DynamicFields))
+	for _, data := range r.DynamicFields {
+		lengths = append(lengths, len(data))
+	}
+	encodedLength, err := EncodeLengths(lengths)
+	if err != nil {
+		return nil, err
+	}
+	mt := r.mudTable()
+	return mt.SetRecordRawCalldata(r.KeyTuple, bytes.Join(r.StaticFields, nil), encodedLength, bytes.Join(r.DynamicFields, nil))
+}
+
+// SetFieldCalldata returns the setStaticField or setDynamicField calldata writing one field,
+// fieldIndex counts static fields first, then dynamic fields
+func (r *Record) SetFieldCalldata(fieldIndex int) ([]byte, error) {
+	mt := r.mudTable()
+	if fieldIndex < len(r.StaticFields) {
+		return mt.SetStaticFieldRawCalldata(r.KeyTuple, fieldIndex, r.StaticFields[fieldIndex])
+	}
+	dynamicIndex := fieldIndex - len(r.StaticFields)
+	return mt.SetDynamicFieldRawCalldata(r.KeyTuple, uint8(dynamicIndex), r.DynamicFields[dynamicIndex])
+}
+
+// DeleteRecordCalldata returns the deleteRecord calldata of the record
+func (r *Record) DeleteRecordCalldata() ([]byte, error) {
+	mt := r.mudTable()
+	return mt.DeleteRecordRawCalldata(r.KeyTuple)
+}
+
+func (r *Record) mudTable() MudTable {
+	return NewMudTable(r.Schema.Name, r.Schema.Namespace, "")
+}
+
+// RecordSet folds World calls into the records they write, like the store would
+type RecordSet struct {
+	records map[string]*Record
+	order   []string // keys in the order the records are first written
+	written map[string]bool
+}
+
+func NewRecordSet() *RecordSet {
+	return &RecordSet{records: make(map[string]*Record), written: make(map[string]bool)}
+}
+
+// Get returns the record with the given RecordKey
+func (rs *RecordSet) Get(key string) (*Record, bool) {
+	r, ok := rs.records[key]
+	return r, ok
+}
+
+// Records returns the existing records in the order they were first written
+func (rs *RecordSet) Records() []*Record {
+	result := make([]*Record, 0, len(rs.records))
+	for _, key := range rs.order {
+		if r, ok := rs.records[key]; ok {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// Apply applies one setRecord, setStaticField, setDynamicField or deleteRecord calldata
+func (rs *RecordSet) Apply(callData []byte) error {
+	method, args, err := unpackCall(callData)
+	if err != nil {
+		return err
+	}
+	tableId := ResourceId(args[0].([32]byte))
+	schema, err := TableSchemaByID(tableId)
+	if err != nil {
+		return err
+	}
+	keyTuple := args[1].([][32]byte)
+	key := RecordKey(tableId, keyTuple)
+	if method.Name == "deleteRecord" {
+		delete(rs.records, key)
+		return nil
+	}
+	r, ok := rs.records[key]
+	if !ok {
+		r = newEmptyRecord(schema, tableId, keyTuple)
+	}
+	switch method.Name {
+	case "setRecord":
+		staticData := args[2].([]byte)
+		if len(staticData) != schema.StaticLength() {
+			return fmt.Errorf("table %s: static data length %d, schema length %d",
+				schema.Name, len(staticData), schema.StaticLength())
+		}
+		for i, f := range schema.Static {
+			offset := schema.StaticFieldOffset(i)
+			r.StaticFields[i] = bytes.Clone(staticData[offset : offset+StaticByteLength(f.Type)])
+		}
+		lengths, err := DecodeLengths(args[3].([32]byte), len(schema.Dynamic))
+		if err != nil {
+			return err
+		}
+		dynamicData := args[4].([]byte)
+		offset := 0
+		for i, length := range lengths {
+			if offset+length > len(dynamicData) {
+				return fmt.Errorf("table %s: dynamic data too short for field %s", schema.Name, schema.Dynamic[i].Name)
+			}
+			r.DynamicFields[i] = bytes.Clone(dynamicData[offset : offset+length])
+			offset += length
+		}
+	case "setStaticField":
+		index := int(args[2].(uint8))
+		if index >= len(schema.Static) {
+			return fmt.Errorf("table %s: static field index %d out of range", schema.Name, index)
+		}
+		r.StaticFields[index] = bytes.Clone(args[3].([]byte))
+	case "setDynamicField":
+		index := int(args[2].(uint8))
+		if index >= len(schema.Dynamic) {
+			return fmt.Errorf("table %s: dynamic field index %d out of range", schema.Name, index)
+		}
+		r.DynamicFields[index] = bytes.Clone(args[3].([]byte))
+	default:
+		return fmt.Errorf("unsupported method %s", method.Name)
+	}
+	rs.records[key] = r
+	if !rs.written[key] {
+		rs.written[key] = true
+		rs.order = append(rs.order, key)
+	}
+	return nil
+}
+
+// newEmptyRecord is a record that was never written: zero static fields and empty dynamic fields
+func newEmptyRecord(schema TableSchema, tableId ResourceId, keyTuple [][32]byte) *Record {
+	r := &Record{
+		Schema:        schema,
+		TableId:       tableId,
+		KeyTuple:      keyTuple,
+		StaticFields:  make([][]byte, len(schema.Static)),
+		DynamicFields: make([][]byte, len(schema.Dynamic)),
+	}
+	for i, f := range schema.Static {
+		r.StaticFields[i] = make([]byte, StaticByteLength(f.Type))
+	}
+	for i := range schema.Dynamic {
+		r.DynamicFields[i] = []byte{}
+	}
+	return r
+}
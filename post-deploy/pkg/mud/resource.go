@@ -0,0 +1,112 @@
+package mud
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceId is bytes2 resource type, bytes14 namespace and bytes16 name
+type ResourceId [32]byte
+
+const (
+	RESOURCE_TABLE          = "tb"
+	RESOURCE_OFFCHAIN_TABLE = "ot"
+	RESOURCE_SYSTEM         = "sy"
+	RESOURCE_NAMESPACE      = "ns"
+
+	RESOURCE_TYPE_BYTES      = 2
+	RESOURCE_NAMESPACE_BYTES = 14
+	RESOURCE_NAME_BYTES      = 16
+)
+
+func isResourceType(resourceType string) bool {
+	switch resourceType {
+	case RESOURCE_TABLE, RESOURCE_OFFCHAIN_TABLE, RESOURCE_SYSTEM, RESOURCE_NAMESPACE:
+		return true
+	}
+	return false
+}
+
+// NewResourceId returns the resource id of the given type, namespace and name,
+// namespace must fit in 14 bytes and name in 16 bytes
+func NewResourceId(resourceType, namespace, name string) (ResourceId, error) {
+	var id ResourceId
+	if !isResourceType(resourceType) {
+		return id, fmt.Errorf("unknown resource type %q", resourceType)
+	}
+	if len(namespace) > RESOURCE_NAMESPACE_BYTES {
+		return id, fmt.Errorf("namespace %q is longer than %d bytes", namespace, RESOURCE_NAMESPACE_BYTES)
+	}
+	if len(name) > RESOURCE_NAME_BYTES {
+		return id, fmt.Errorf("name %q is longer than %d bytes", name, RESOURCE_NAME_BYTES)
+	}
+	copy(id[:RESOURCE_TYPE_BYTES], resourceType)
+	copy(id[RESOURCE_TYPE_BYTES:], namespace)
+	copy(id[RESOURCE_TYPE_BYTES+RESOURCE_NAMESPACE_BYTES:], name)
+	return id, nil
+}
+
+// NewTableId returns the resource id of an onchain table
+func NewTableId(namespace, name string) (ResourceId, error) {
+	return NewResourceId(RESOURCE_TABLE, namespace, name)
+}
+
+// NewOffchainTableId returns the resource id of an offchain table
+func NewOffchainTableId(namespace, name string) (ResourceId, error) {
+	return NewResourceId(RESOURCE_OFFCHAIN_TABLE, namespace, name)
+}
+
+// NewSystemId returns the resource id of a system
+func NewSystemId(namespace, name string) (ResourceId, error) {
+	return NewResourceId(RESOURCE_SYSTEM, namespace, name)
+}
+
+// NewNamespaceId returns the resource id of a namespace, the name part is empty
+func NewNamespaceId(namespace string) (ResourceId, error) {
+	return NewResourceId(RESOURCE_NAMESPACE, namespace, "")
+}
+
+// ResourceIdFromBytes parses a bytes32 resource id and checks its type
+func ResourceIdFromBytes(data []byte) (ResourceId, error) {
+	var id ResourceId
+	if len(data) != len(id) {
+		return id, fmt.Errorf("resource id must be %d bytes, got %d", len(id), len(data))
+	}
+	copy(id[:], data)
+	if !isResourceType(id.Type()) {
+		return id, fmt.Errorf("unknown resource type %q", id.Type())
+	}
+	if id.Type() == RESOURCE_NAMESPACE && id.Name() != "" {
+		return id, fmt.Errorf("namespace id %s has a name", id)
+	}
+	return id, nil
+}
+
+// ParseResourceId parses the type:namespace:name form returned by ResourceId.String
+func ParseResourceId(s string) (ResourceId, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return ResourceId{}, fmt.Errorf("resource id %q is not type:namespace:name", s)
+	}
+	return NewResourceId(parts[0], parts[1], parts[2])
+}
+
+// Type returns the resource type, e.g. tb or sy
+func (r ResourceId) Type() string {
+	return strings.TrimRight(string(r[:RESOURCE_TYPE_BYTES]), "\x00")
+}
+
+// Namespace returns the namespace without padding
+func (r ResourceId) Namespace() string {
+	return strings.TrimRight(string(r[RESOURCE_TYPE_BYTES:RESOURCE_TYPE_BYTES+RESOURCE_NAMESPACE_BYTES]), "\x00")
+}
+
+// Name returns the name without padding
+func (r ResourceId) Name() string {
+	return strings.TrimRight(string(r[RESOURCE_TYPE_BYTES+RESOURCE_NAMESPACE_BYTES:]), "\x00")
+}
+
+// String returns type:namespace:name
+func (r ResourceId) String() string {
+	return fmt.Sprintf("%s:%s:%s", r.Type(), r.Namespace(), r.Name())
+}
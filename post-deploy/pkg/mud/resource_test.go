@@ -0,0 +1,86 @@
+package mud
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceId(t *testing.T) {
+	tests := []struct {
+		build func() (ResourceId, error)
+		hex   string
+		str   string
+	}{
+		{
+			build: func() (ResourceId, error) { return NewTableId("app", "TileInfo3") },
+			hex:   "0x7462617070000000000000000000000054696c65496e666f3300000000000000",
+			str:   "tb:app:TileInfo3",
+		},
+		{
+			build: func() (ResourceId, error) { return NewOffchainTableId("app", "ChatLog") },
+			hex:   "0x6f746170700000000000000000000000436861744c6f67000000000000000000",
+			str:   "ot:app:ChatLog",
+		},
+		{
+			build: func() (ResourceId, error) { return NewSystemId("app", "SaleSystem") },
+			hex:   "0x7379617070000000000000000000000053616c6553797374656d000000000000",
+			str:   "sy:app:SaleSystem",
+		},
+		{
+			build: func() (ResourceId, error) { return NewNamespaceId("app") },
+			hex:   "0x6e73617070000000000000000000000000000000000000000000000000000000",
+			str:   "ns:app:",
+		},
+	}
+	for _, tc := range tests {
+		id, err := tc.build()
+		require.NoError(t, err)
+		require.Equal(t, tc.hex, hexutil.Encode(id[:]))
+		require.Equal(t, tc.str, id.String())
+
+		parsed, err := ResourceIdFromBytes(id[:])
+		require.NoError(t, err)
+		require.Equal(t, id, parsed)
+		parsed, err = ParseResourceId(tc.str)
+		require.NoError(t, err)
+		require.Equal(t, id, parsed)
+	}
+}
+
+func TestResourceIdValidation(t *testing.T) {
+	_, err := NewTableId("namespaceTooLong", "Table")
+	require.Error(t, err)
+	_, err = NewSystemId("app", "ThisNameIsTooLongForMud")
+	require.Error(t, err)
+	_, err = NewResourceId("xx", "app", "Table")
+	require.Error(t, err)
+	_, err = ResourceIdFromBytes(make([]byte, 31))
+	require.Error(t, err)
+	_, err = ResourceIdFromBytes(make([]byte, 32))
+	require.Error(t, err)
+	_, err = ParseResourceId("app:Table")
+	require.Error(t, err)
+}
+
+func TestWorldAccessCalldata(t *testing.T) {
+	systemId, err := NewSystemId("app", "SaleSystem")
+	require.NoError(t, err)
+	grantee := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	callData, err := GrantAccessCalldata(systemId, grantee)
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Encode(expectedCall("grantAccess(bytes32,address)", systemId[:], common.LeftPadBytes(grantee.Bytes(), 32))),
+		hexutil.Encode(callData))
+
+	callData, err = CallSystemCalldata(systemId, []byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Encode(expectedCall("call(bytes32,bytes)", systemId[:], abiWord(64), abiBytes([]byte{1, 2, 3, 4}))),
+		hexutil.Encode(callData))
+
+	tableId, err := NewTableId("app", "TileInfo3")
+	require.NoError(t, err)
+	_, err = CallSystemCalldata(tableId, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,211 @@
+package mud
+
+//go:generate go run ./gen-schema -root ../../.. -out schemas_gen.go
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FieldSchema is one column of a MUD table
+type FieldSchema struct {
+	Name string
+	Type string // solidity type, enums are stored as uint8
+	Enum string // enum name when the column is declared with an enum type
+}
+
+// TableSchema is the schema of a MUD table as declared in mud.config.ts
+type TableSchema struct {
+	Name      string
+	Namespace string
+	Key       []FieldSchema
+	Static    []FieldSchema
+	Dynamic   []FieldSchema
+}
+
+// GetTableSchema returns the schema of the given table from the generated registry
+func GetTableSchema(tableName string) (TableSchema, error) {
+	schema, ok := Tables[tableName]
+	if !ok {
+		return TableSchema{}, fmt.Errorf("unknown table %s", tableName)
+	}
+	return schema, nil
+}
+
+// StaticLength returns the total byte length of the static fields
+func (ts TableSchema) StaticLength() int {
+	total := 0
+	for _, f := range ts.Static {
+		total += StaticByteLength(f.Type)
+	}
+	return total
+}
+
+// StaticFieldOffset returns the byte offset of a static field within the static data
+func (ts TableSchema) StaticFieldOffset(index int) int {
+	offset := 0
+	for _, f := range ts.Static[:index] {
+		offset += StaticByteLength(f.Type)
+	}
+	return offset
+}
+
+// IsDynamicType returns true if the type is stored in the dynamic part of a record.
+// MUD stores both T[] and T[N] arrays as dynamic fields.
+func IsDynamicType(solType string) bool {
+	return solType == "string" || solType == "bytes" || strings.HasSuffix(solType, "]")
+}
+
+// ArrayElementType returns the element type of T[] or T[N] and the fixed length (0 for T[])
+func ArrayElementType(solType string) (string, int, bool) {
+	if !strings.HasSuffix(solType, "]") {
+		return "", 0, false
+	}
+	open := strings.LastIndex(solType, "[")
+	if open < 0 {
+		return "", 0, false
+	}
+	elem := solType[:open]
+	rawLen := solType[open+1 : len(solType)-1]
+	if rawLen == "" {
+		return elem, 0, true
+	}
+	n, err := strconv.Atoi(rawLen)
+	if err != nil {
+		return "", 0, false
+	}
+	return elem, n, true
+}
+
+// StaticByteLength returns the packed byte length of a static solidity type, 0 if the type is not static
+func StaticByteLength(solType string) int {
+	switch {
+	case solType == "bool":
+		return 1
+	case solType == "address":
+		return 20
+	case strings.HasPrefix(solType, "uint"):
+		return bitSize(solType[len("uint"):]) / BYTE_TO_BITS
+	case strings.HasPrefix(solType, "int"):
+		return bitSize(solType[len("int"):]) / BYTE_TO_BITS
+	case strings.HasPrefix(solType, "bytes") && solType != "bytes":
+		n, err := strconv.Atoi(solType[len("bytes"):])
+		if err != nil {
+			return 0
+		}
+		return n
+	}
+	return 0
+}
+
+// DynamicElementLength returns the byte length of one element of a dynamic type
+func DynamicElementLength(solType string) int {
+	if solType == "string" || solType == "bytes" {
+		return 1
+	}
+	elem, _, ok := ArrayElementType(solType)
+	if !ok {
+		return 0
+	}
+	return StaticByteLength(elem)
+}
+
+func bitSize(s string) int {
+	if s == "" {
+		return 256
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// DecodeStaticData splits packed static data into one value per static field
+func (ts TableSchema) DecodeStaticData(data []byte) ([]interface{}, error) {
+	if len(data) != ts.StaticLength() {
+		return nil, fmt.Errorf("table %s: static data length %d, expected %d", ts.Name, len(data), ts.StaticLength())
+	}
+	values := make([]interface{}, 0, len(ts.Static))
+	offset := 0
+	for _, f := range ts.Static {
+		size := StaticByteLength(f.Type)
+		values = append(values, DecodeStaticValue(f.Type, data[offset:offset+size]))
+		offset += size
+	}
+	return values, nil
+}
+
+// DecodeDynamicData splits dynamic data into one value per dynamic field using the packed counter
+func (ts TableSchema) DecodeDynamicData(encodedLengths PackedCounter, data []byte) ([]interface{}, error) {
+	lengths, err := DecodeLengths(encodedLengths, len(ts.Dynamic))
+	if err != nil {
+		return nil, fmt.Errorf("table %s: %w", ts.Name, err)
+	}
+	values := make([]interface{}, 0, len(ts.Dynamic))
+	offset := 0
+	for index, f := range ts.Dynamic {
+		length := lengths[index]
+		if offset+length > len(data) {
+			return nil, fmt.Errorf("table %s: dynamic field %s out of range", ts.Name, f.Name)
+		}
+		value, err := DecodeDynamicValue(f.Type, data[offset:offset+length])
+		if err != nil {
+			return nil, fmt.Errorf("table %s: field %s: %w", ts.Name, f.Name, err)
+		}
+		values = append(values, value)
+		offset += length
+	}
+	if offset != len(data) {
+		return nil, fmt.Errorf("table %s: dynamic data length %d, encoded lengths sum %d", ts.Name, len(data), offset)
+	}
+	return values, nil
+}
+
+// DecodeStaticValue decodes one packed static value.
+// Integers are returned as *big.Int, bool as bool, address and bytesN as hex.
+func DecodeStaticValue(solType string, data []byte) interface{} {
+	switch {
+	case solType == "bool":
+		return data[0] != 0
+	case solType == "address":
+		return common.BytesToAddress(data)
+	case strings.HasPrefix(solType, "uint"):
+		return new(big.Int).SetBytes(data)
+	case strings.HasPrefix(solType, "int"):
+		v := new(big.Int).SetBytes(data)
+		if len(data) > 0 && data[0]&0x80 != 0 {
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(data)*BYTE_TO_BITS)))
+		}
+		return v
+	}
+	return hexutil.Bytes(data)
+}
+
+// DecodeDynamicValue decodes one dynamic field, arrays are returned as []interface{}
+func DecodeDynamicValue(solType string, data []byte) (interface{}, error) {
+	switch solType {
+	case "string":
+		return string(data), nil
+	case "bytes":
+		return hexutil.Bytes(data), nil
+	}
+	elem, _, ok := ArrayElementType(solType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported dynamic type %s", solType)
+	}
+	size := StaticByteLength(elem)
+	if size == 0 || len(data)%size != 0 {
+		return nil, fmt.Errorf("invalid data length %d for %s", len(data), solType)
+	}
+	values := make([]interface{}, 0, len(data)/size)
+	for i := 0; i < len(data); i += size {
+		values = append(values, DecodeStaticValue(elem, data[i:i+size]))
+	}
+	return values, nil
+}
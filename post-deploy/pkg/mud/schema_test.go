@@ -0,0 +1,31 @@
+package mud
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeFieldLayout(t *testing.T) {
+	tests := map[string]string{
+		"TileInfo3":       "0x0043050301010120200000000000000000000000000000000000000000000000",
+		"ItemWeightCache": "0x0004010004000000000000000000000000000000000000000000000000000000",
+		"SkinInfo":        "0x0001010001000000000000000000000000000000000000000000000000000000",
+		"CollectionExcV2": "0x0000000200000000000000000000000000000000000000000000000000000000",
+	}
+	for tableName, expected := range tests {
+		schema, err := GetTableSchema(tableName)
+		require.NoError(t, err)
+		fl, err := EncodeFieldLayout(schema)
+		require.NoError(t, err)
+		require.Equal(t, expected, hexutil.Encode(fl[:]), tableName)
+	}
+}
+
+func TestEncodeFieldLayoutAllTables(t *testing.T) {
+	for tableName, schema := range Tables {
+		_, err := EncodeFieldLayout(schema)
+		require.NoError(t, err, tableName)
+	}
+}
@@ -0,0 +1,2004 @@
+// Code generated by gen-schema from mud.config.ts; DO NOT EDIT.
+
+package mud
+
+// Enums contains the enum values declared in mud.config.ts
+var Enums = map[string][]string{
+	"AdvantageType":      {"Red", "Green", "Blue", "Grey"},
+	"BuffType":           {"None", "StatsModify", "ExpAmplify", "InstantDamage", "InstantHeal", "HealingPotion"},
+	"CharacterStateType": {"Standby", "Farming", "Moving", "Hunting"},
+	"CharacterType":      {"Male", "Female"},
+	"CurrencyType":       {"Gold", "Crystal"},
+	"EffectType":         {"None", "Burn", "Poison", "Frostbite", "Stun"},
+	"EntityType":         {"Character", "Monster"},
+	"GachaType":          {"OpenBox"},
+	"ItemCategoryType":   {"Tool", "Equipment", "Other"},
+	"ItemType":           {"WoodAxe", "StoneHammer", "FishingRod", "Pickaxe", "Sickle", "BerryShears", "Sword", "Axe", "Spear", "Bow", "Staff", "Dagger", "Shield", "ClothArmor", "ClothHeadgear", "ClothFootwear", "LeatherArmor", "LeatherHeadgear", "LeatherFootwear", "PlateArmor", "PlateHeadgear", "PlateFootwear", "Mount", "Resource", "SkillItem", "HealingItem", "StatModifierItem", "Card", "BuffItem", "Pet", "GachaTicket", "Skin", "Teleport", "Ring", "Bundle", "CraftingMaterial"},
+	"PetComponentType":   {"Bag", "Eye", "Horn", "Mouth", "Tail", "Wing", "Body", "Head", "Weapon"},
+	"QuestStatusType":    {"NotReceived", "InProgress", "Done"},
+	"QuestType":          {"Contribute", "Locate"},
+	"ResourceType":       {"Wood", "Stone", "Fish", "Ore", "Wheat", "Berry", "MonsterLoot"},
+	"RoleType":           {"None", "VaultKeeper", "KingGuard"},
+	"SkinSlotType":       {"Weapon", "SubWeapon", "Armor", "Headgear", "Footwear", "Aura", "Wings", "Cloak"},
+	"SlotType":           {"Weapon", "SubWeapon", "Armor", "Headgear", "Footwear", "Mount", "Pet", "Ring"},
+	"SocialType":         {"Twitter", "Telegram", "Discord"},
+	"StatType":           {"ATK", "DEF", "AGI"},
+	"TerrainType":        {"GrassLand", "Forest", "Mountain"},
+	"ZoneType":           {"Green", "Orange", "Red", "Black"},
+}
+
+// Tables contains the schema of every table declared in mud.config.ts
+var Tables = map[string]TableSchema{
+	"Achievement": {
+		Name:      "Achievement",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "atk", Type: "uint16"},
+			{Name: "def", Type: "uint16"},
+			{Name: "agi", Type: "uint16"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "name", Type: "string"},
+		},
+	},
+	"ActiveChar": {
+		Name:      "ActiveChar",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "wallet", Type: "address"},
+			{Name: "sessionWallet", Type: "address"},
+			{Name: "createdTime", Type: "uint256"},
+		},
+	},
+	"AllianceV2": {
+		Name:      "AllianceV2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "kingdomA", Type: "uint8"},
+			{Name: "kingdomB", Type: "uint8"},
+		},
+		Static: []FieldSchema{
+			{Name: "isAlliance", Type: "bool"},
+			{Name: "isApproved", Type: "bool"},
+		},
+	},
+	"BossInfo": {
+		Name:      "BossInfo",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "monsterId", Type: "uint256"},
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+		},
+		Static: []FieldSchema{
+			{Name: "barrier", Type: "uint32"},
+			{Name: "hp", Type: "uint32"},
+			{Name: "crystal", Type: "uint32"},
+			{Name: "respawnDuration", Type: "uint16"},
+			{Name: "berserkHpThreshold", Type: "uint8"},
+			{Name: "boostPercent", Type: "uint8"},
+			{Name: "lastDefeatedTime", Type: "uint256"},
+		},
+	},
+	"BuffDmg": {
+		Name:      "BuffDmg",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "dmg", Type: "uint32"},
+			{Name: "isAbsDmg", Type: "bool"},
+		},
+	},
+	"BuffExp": {
+		Name:      "BuffExp",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "farmingPerkAmp", Type: "uint16"},
+			{Name: "pveExpAmp", Type: "uint16"},
+			{Name: "pvePerkAmp", Type: "uint16"},
+		},
+	},
+	"BuffItemInfoV3": {
+		Name:      "BuffItemInfoV3",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "range", Type: "uint16"},
+			{Name: "duration", Type: "uint32"},
+			{Name: "numTarget", Type: "uint8"},
+			{Name: "selfCastOnly", Type: "bool"},
+			{Name: "buffType", Type: "uint8", Enum: "BuffType"},
+			{Name: "isBuff", Type: "bool"},
+		},
+	},
+	"BuffStatV4": {
+		Name:      "BuffStatV4",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "atkPercent", Type: "int16"},
+			{Name: "defPercent", Type: "int16"},
+			{Name: "agiPercent", Type: "int16"},
+			{Name: "sp", Type: "int8"},
+			{Name: "ms", Type: "int8"},
+			{Name: "slowPercent", Type: "uint16"},
+			{Name: "dmg", Type: "uint32"},
+			{Name: "isAbsDmg", Type: "bool"},
+		},
+	},
+	"CResourceRequire": {
+		Name:      "CResourceRequire",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "level", Type: "uint8"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "resourceIds", Type: "uint256[]"},
+			{Name: "amounts", Type: "uint32[]"},
+		},
+	},
+	"CVaultHistoryV4": {
+		Name:      "CVaultHistoryV4",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "cityId", Type: "uint256"},
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "gold", Type: "uint32"},
+			{Name: "crystal", Type: "uint256"},
+			{Name: "timestamp", Type: "uint256"},
+			{Name: "isContributed", Type: "bool"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "itemIds", Type: "uint256[]"},
+			{Name: "amounts", Type: "uint32[]"},
+		},
+	},
+	"CandidatePromise": {
+		Name:      "CandidatePromise",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "candidateId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "timestamp", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "content", Type: "string"},
+		},
+	},
+	"CardInfo": {
+		Name:      "CardInfo",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "top", Type: "uint16"},
+			{Name: "left", Type: "uint16"},
+			{Name: "right", Type: "uint16"},
+			{Name: "bottom", Type: "uint16"},
+		},
+	},
+	"CharAchievement": {
+		Name:      "CharAchievement",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "charId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "achievementIds", Type: "uint256[]"},
+		},
+	},
+	"CharAchievementIndex": {
+		Name:      "CharAchievementIndex",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "charId", Type: "uint256"},
+			{Name: "achievementId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "index", Type: "uint256"},
+		},
+	},
+	"CharBaseStats": {
+		Name:      "CharBaseStats",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "atk", Type: "uint16"},
+			{Name: "def", Type: "uint16"},
+			{Name: "agi", Type: "uint16"},
+		},
+	},
+	"CharBattle": {
+		Name:      "CharBattle",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "lastPvpId", Type: "uint256"},
+			{Name: "pvpLastAtkTime", Type: "uint256"},
+			{Name: "pvpLastDefTime", Type: "uint256"},
+			{Name: "pveLastAtkTime", Type: "uint256"},
+		},
+	},
+	"CharBuff": {
+		Name:      "CharBuff",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "buffIds", Type: "uint256[2]"},
+			{Name: "expireTimes", Type: "uint256[2]"},
+		},
+	},
+	"CharBuffCounter": {
+		Name:      "CharBuffCounter",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "buffType", Type: "uint8", Enum: "BuffType"},
+		},
+		Static: []FieldSchema{
+			{Name: "count", Type: "uint32"},
+		},
+	},
+	"CharCStats2": {
+		Name:      "CharCStats2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "barrier", Type: "uint32"},
+		},
+	},
+	"CharCollection": {
+		Name:      "CharCollection",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "amount", Type: "uint32"},
+		},
+	},
+	"CharCurrentStats": {
+		Name:      "CharCurrentStats",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "exp", Type: "uint32"},
+			{Name: "weight", Type: "uint32"},
+			{Name: "hp", Type: "uint32"},
+			{Name: "atk", Type: "uint16"},
+			{Name: "def", Type: "uint16"},
+			{Name: "agi", Type: "uint16"},
+			{Name: "ms", Type: "uint16"},
+		},
+	},
+	"CharDailyQuest": {
+		Name:      "CharDailyQuest",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "moveCount", Type: "uint8"},
+			{Name: "farmCount", Type: "uint8"},
+			{Name: "pvpCount", Type: "uint8"},
+			{Name: "pveCount", Type: "uint8"},
+			{Name: "streak", Type: "uint8"},
+			{Name: "startTime", Type: "uint256"},
+		},
+	},
+	"CharDebuff": {
+		Name:      "CharDebuff",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "debuffIds", Type: "uint256[2]"},
+			{Name: "expireTimes", Type: "uint256[2]"},
+		},
+	},
+	"CharDebuff2": {
+		Name:      "CharDebuff2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "lastCastTime", Type: "uint256"},
+		},
+	},
+	"CharEquipStats": {
+		Name:      "CharEquipStats",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "slotType", Type: "uint8", Enum: "SlotType"},
+		},
+		Static: []FieldSchema{
+			{Name: "hp", Type: "uint32"},
+			{Name: "atk", Type: "uint16"},
+			{Name: "def", Type: "uint16"},
+			{Name: "agi", Type: "uint16"},
+			{Name: "ms", Type: "uint16"},
+		},
+	},
+	"CharEquipStats2": {
+		Name:      "CharEquipStats2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "slotType", Type: "uint8", Enum: "SlotType"},
+		},
+		Static: []FieldSchema{
+			{Name: "barrier", Type: "uint32"},
+			{Name: "weight", Type: "uint32"},
+		},
+	},
+	"CharEquipment": {
+		Name:      "CharEquipment",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "slotType", Type: "uint8", Enum: "SlotType"},
+		},
+		Static: []FieldSchema{
+			{Name: "equipmentId", Type: "uint256"},
+		},
+	},
+	"CharExpAmp": {
+		Name:      "CharExpAmp",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "farmingPerkAmp", Type: "uint16"},
+			{Name: "pveExpAmp", Type: "uint16"},
+			{Name: "pvePerkAmp", Type: "uint16"},
+			{Name: "expireTime", Type: "uint256"},
+		},
+	},
+	"CharFarmingState": {
+		Name:      "CharFarmingState",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+			{Name: "toolId", Type: "uint256"},
+			{Name: "itemType", Type: "uint8", Enum: "ItemType"},
+		},
+	},
+	"CharFund": {
+		Name:      "CharFund",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "gold", Type: "uint32"},
+			{Name: "crystal", Type: "uint32"},
+		},
+	},
+	"CharGachaReq": {
+		Name:      "CharGachaReq",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "requestId", Type: "uint256"},
+		},
+	},
+	"CharGachaV3": {
+		Name:      "CharGachaV3",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "requestId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "randomNumber", Type: "uint256"},
+			{Name: "gachaId", Type: "uint256"},
+			{Name: "isLimitedGacha", Type: "bool"},
+			{Name: "gachaItemId", Type: "uint256"},
+			{Name: "gachaEquipmentId", Type: "uint256"},
+			{Name: "isPending", Type: "bool"},
+			{Name: "timestamp", Type: "uint256"},
+		},
+	},
+	"CharGrindSlot": {
+		Name:      "CharGrindSlot",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "slotType", Type: "uint8", Enum: "SlotType"},
+		},
+	},
+	"CharInfo": {
+		Name:      "CharInfo",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "kingdomId", Type: "uint8"},
+			{Name: "characterType", Type: "uint8", Enum: "CharacterType"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "traits", Type: "uint16[3]"},
+			{Name: "name", Type: "string"},
+		},
+	},
+	"CharInventory": {
+		Name:      "CharInventory",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "toolIds", Type: "uint256[]"},
+			{Name: "equipmentIds", Type: "uint256[]"},
+		},
+	},
+	"CharMarketWeight": {
+		Name:      "CharMarketWeight",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "cityId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "weight", Type: "uint32"},
+			{Name: "maxWeight", Type: "uint32"},
+		},
+	},
+	"CharMigration": {
+		Name:      "CharMigration",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "equipmentId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "isMigrate", Type: "bool"},
+		},
+	},
+	"CharName": {
+		Name:      "CharName",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "nameHash", Type: "bytes32"},
+		},
+		Static: []FieldSchema{
+			{Name: "owner", Type: "uint256"},
+		},
+	},
+	"CharNextPosition": {
+		Name:      "CharNextPosition",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+			{Name: "arriveTimestamp", Type: "uint256"},
+		},
+	},
+	"CharOtherItem": {
+		Name:      "CharOtherItem",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "charId", Type: "uint256"},
+			{Name: "amount", Type: "uint32"},
+		},
+	},
+	"CharOtherItemStorage": {
+		Name:      "CharOtherItemStorage",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "cityId", Type: "uint256"},
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "charId", Type: "uint256"},
+			{Name: "amount", Type: "uint32"},
+		},
+	},
+	"CharPerk": {
+		Name:      "CharPerk",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "itemType", Type: "uint8", Enum: "ItemType"},
+		},
+		Static: []FieldSchema{
+			{Name: "exp", Type: "uint32"},
+			{Name: "level", Type: "uint8"},
+		},
+	},
+	"CharPosition": {
+		Name:      "CharPosition",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+		},
+	},
+	"CharPositionV2": {
+		Name:      "CharPositionV2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+			{Name: "nextX", Type: "int32"},
+			{Name: "nextY", Type: "int32"},
+			{Name: "arriveTimestamp", Type: "uint256"},
+		},
+	},
+	"CharQuestStatus": {
+		Name:      "CharQuestStatus",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "questId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "questStatus", Type: "uint8", Enum: "QuestStatusType"},
+		},
+	},
+	"CharReborn": {
+		Name:      "CharReborn",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "num", Type: "uint16"},
+		},
+	},
+	"CharRole": {
+		Name:      "CharRole",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "roleType", Type: "uint8", Enum: "RoleType"},
+		},
+	},
+	"CharRoleCounter": {
+		Name:      "CharRoleCounter",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "kingdomId", Type: "uint256"},
+			{Name: "roleType", Type: "uint8", Enum: "RoleType"},
+		},
+		Static: []FieldSchema{
+			{Name: "count", Type: "uint32"},
+		},
+	},
+	"CharSavePoint": {
+		Name:      "CharSavePoint",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "cityId", Type: "uint256"},
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+		},
+	},
+	"CharSkill": {
+		Name:      "CharSkill",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "skillIds", Type: "uint256[5]"},
+		},
+	},
+	"CharSkin": {
+		Name:      "CharSkin",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "slotType", Type: "uint8", Enum: "SkinSlotType"},
+		},
+		Static: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+	},
+	"CharSocialQuest": {
+		Name:      "CharSocialQuest",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "twitter", Type: "bool"},
+			{Name: "telegram", Type: "bool"},
+			{Name: "discord", Type: "bool"},
+		},
+	},
+	"CharState": {
+		Name:      "CharState",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "state", Type: "uint8", Enum: "CharacterStateType"},
+			{Name: "lastUpdated", Type: "uint256"},
+		},
+	},
+	"CharStats": {
+		Name:      "CharStats",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "weight", Type: "uint32"},
+			{Name: "hp", Type: "uint32"},
+			{Name: "level", Type: "uint16"},
+			{Name: "statPoint", Type: "uint16"},
+			{Name: "sp", Type: "uint8"},
+		},
+	},
+	"CharStats2": {
+		Name:      "CharStats2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "fame", Type: "uint32"},
+		},
+	},
+	"CharStorage": {
+		Name:      "CharStorage",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "cityId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "maxWeight", Type: "uint32"},
+			{Name: "weight", Type: "uint32"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "toolIds", Type: "uint256[]"},
+			{Name: "equipmentIds", Type: "uint256[]"},
+		},
+	},
+	"CharStorageMigration": {
+		Name:      "CharStorageMigration",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "equipmentId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "isMigrate", Type: "bool"},
+		},
+	},
+	"CharSupply": {
+		Name:      "CharSupply",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "totalSupply", Type: "uint256"},
+		},
+	},
+	"CharTotalSpend": {
+		Name:      "CharTotalSpend",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "total", Type: "uint256"},
+		},
+	},
+	"CharVaultWithdraw": {
+		Name:      "CharVaultWithdraw",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "weightQuota", Type: "uint32"},
+			{Name: "markTimestamp", Type: "uint256"},
+		},
+	},
+	"CharVote": {
+		Name:      "CharVote",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "candidateId", Type: "uint256"},
+			{Name: "votePower", Type: "uint32"},
+			{Name: "timestamp", Type: "uint256"},
+		},
+	},
+	"ChatCounter": {
+		Name:      "ChatCounter",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "counter", Type: "uint256"},
+		},
+	},
+	"City": {
+		Name:      "City",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+			{Name: "isCapital", Type: "bool"},
+			{Name: "kingdomId", Type: "uint8"},
+			{Name: "level", Type: "uint8"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "name", Type: "string"},
+		},
+	},
+	"CityCounter": {
+		Name:      "CityCounter",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "counter", Type: "uint256"},
+		},
+	},
+	"CityMoveHistory": {
+		Name:      "CityMoveHistory",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "cityId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "oldPositionX", Type: "int32"},
+			{Name: "oldPositionY", Type: "int32"},
+			{Name: "moveTimestamp", Type: "uint256"},
+		},
+	},
+	"CityVault": {
+		Name:      "CityVault",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "cityId", Type: "uint256"},
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "amount", Type: "uint32"},
+		},
+	},
+	"CityVault2V2": {
+		Name:      "CityVault2V2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "cityId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "gold", Type: "uint32"},
+			{Name: "crystal", Type: "uint256"},
+		},
+	},
+	"CollectionExcV2": {
+		Name:      "CollectionExcV2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "inputItemIds", Type: "uint256[]"},
+			{Name: "inputItemAmounts", Type: "uint32[]"},
+		},
+	},
+	"Contracts": {
+		Name:      "Contracts",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "erc721Token", Type: "address"},
+			{Name: "erc20Token", Type: "address"},
+		},
+	},
+	"CrystalFee": {
+		Name:      "CrystalFee",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "kingdomId", Type: "uint8"},
+		},
+		Static: []FieldSchema{
+			{Name: "fee", Type: "uint8"},
+		},
+	},
+	"DailyQuestConfig": {
+		Name:      "DailyQuestConfig",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "moveNum", Type: "uint8"},
+			{Name: "farmNum", Type: "uint8"},
+			{Name: "pvpNum", Type: "uint8"},
+			{Name: "pveNum", Type: "uint8"},
+			{Name: "rewardExp", Type: "uint32"},
+			{Name: "rewardGold", Type: "uint32"},
+		},
+	},
+	"DropResource": {
+		Name:      "DropResource",
+		Namespace: "app",
+		Dynamic: []FieldSchema{
+			{Name: "resourceIds", Type: "uint256[]"},
+		},
+	},
+	"EPetStats": {
+		Name:      "EPetStats",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "equipmentId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "atk", Type: "uint16"},
+			{Name: "def", Type: "uint16"},
+			{Name: "agi", Type: "uint16"},
+		},
+	},
+	"Equipment": {
+		Name:      "Equipment",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+			{Name: "characterId", Type: "uint256"},
+			{Name: "level", Type: "uint8"},
+			{Name: "counter", Type: "uint8"},
+		},
+	},
+	"Equipment2": {
+		Name:      "Equipment2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "authorId", Type: "uint256"},
+		},
+	},
+	"EquipmentInfo": {
+		Name:      "EquipmentInfo",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "slotType", Type: "uint8", Enum: "SlotType"},
+			{Name: "advantageType", Type: "uint8", Enum: "AdvantageType"},
+			{Name: "twoHanded", Type: "bool"},
+			{Name: "hp", Type: "uint32"},
+			{Name: "atk", Type: "uint16"},
+			{Name: "def", Type: "uint16"},
+			{Name: "agi", Type: "uint16"},
+			{Name: "ms", Type: "uint16"},
+		},
+	},
+	"EquipmentInfo2V2": {
+		Name:      "EquipmentInfo2V2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "maxLevel", Type: "uint8"},
+			{Name: "counter", Type: "uint8"},
+			{Name: "dmgPercent", Type: "uint16"},
+			{Name: "bonusWeight", Type: "uint32"},
+			{Name: "shieldBarrier", Type: "uint32"},
+		},
+	},
+	"EquipmentPet": {
+		Name:      "EquipmentPet",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "equipmentId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "petId", Type: "uint256"},
+		},
+	},
+	"EquipmentSupply": {
+		Name:      "EquipmentSupply",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "totalSupply", Type: "uint256"},
+		},
+	},
+	"ExpAmpConfig": {
+		Name:      "ExpAmpConfig",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "farmingPerkAmp", Type: "uint16"},
+			{Name: "pveExpAmp", Type: "uint16"},
+			{Name: "pvePerkAmp", Type: "uint16"},
+			{Name: "expireTime", Type: "uint256"},
+		},
+	},
+	"FillCounter": {
+		Name:      "FillCounter",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "counter", Type: "uint256"},
+		},
+	},
+	"FillOrder": {
+		Name:      "FillOrder",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "cityId", Type: "uint256"},
+			{Name: "characterId", Type: "uint256"},
+			{Name: "equipmentId", Type: "uint256"},
+			{Name: "itemId", Type: "uint256"},
+			{Name: "amount", Type: "uint32"},
+			{Name: "unitPrice", Type: "uint32"},
+			{Name: "isBuy", Type: "bool"},
+			{Name: "filledAt", Type: "uint256"},
+		},
+	},
+	"FillOrder2V2": {
+		Name:      "FillOrder2V2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "fillOrderId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "makerOrderId", Type: "uint256"},
+			{Name: "makerId", Type: "uint256"},
+			{Name: "currency", Type: "uint8", Enum: "CurrencyType"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "equipmentIds", Type: "uint256[]"},
+		},
+	},
+	"GachaCounter": {
+		Name:      "GachaCounter",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "count", Type: "uint256"},
+		},
+	},
+	"GachaItemIndex": {
+		Name:      "GachaItemIndex",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "gachaId", Type: "uint256"},
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "index", Type: "uint256"},
+		},
+	},
+	"GachaPet": {
+		Name:      "GachaPet",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "startTime", Type: "uint256"},
+			{Name: "endTime", Type: "uint256"},
+			{Name: "ticketValue", Type: "uint256"},
+			{Name: "ticketItemId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "petIds", Type: "uint256[]"},
+		},
+	},
+	"GachaReqInfo": {
+		Name:      "GachaReqInfo",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "requestId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "gachaType", Type: "uint8", Enum: "GachaType"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "extraData", Type: "bytes"},
+		},
+	},
+	"GachaV5": {
+		Name:      "GachaV5",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "startTime", Type: "uint256"},
+			{Name: "ticketValue", Type: "uint256"},
+			{Name: "ticketItemId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "itemIds", Type: "uint256[]"},
+			{Name: "amounts", Type: "uint32[]"},
+			{Name: "percents", Type: "uint16[]"},
+		},
+	},
+	"GlobalChatV2": {
+		Name:      "GlobalChatV2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "charId", Type: "uint256"},
+			{Name: "timestamp", Type: "uint256"},
+			{Name: "rawId", Type: "uint256"},
+			{Name: "kingdomId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "name", Type: "string"},
+			{Name: "content", Type: "string"},
+		},
+	},
+	"Guild": {
+		Name:      "Guild",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "leaderId", Type: "uint256"},
+			{Name: "level", Type: "uint32"},
+			{Name: "createdAt", Type: "uint256"},
+			{Name: "point", Type: "uint32"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "name", Type: "string"},
+			{Name: "memberIds", Type: "uint256[]"},
+		},
+	},
+	"GuildCounter": {
+		Name:      "GuildCounter",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "count", Type: "uint256"},
+		},
+	},
+	"GuildMemberIndex": {
+		Name:      "GuildMemberIndex",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "guildId", Type: "uint256"},
+			{Name: "memberId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "index", Type: "uint256"},
+		},
+	},
+	"GuildMemberMapping": {
+		Name:      "GuildMemberMapping",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "memberId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "guildId", Type: "uint256"},
+		},
+	},
+	"GuildNameMapping": {
+		Name:      "GuildNameMapping",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "nameHash", Type: "bytes32"},
+		},
+		Static: []FieldSchema{
+			{Name: "guildId", Type: "uint256"},
+		},
+	},
+	"GuildOwnerMapping": {
+		Name:      "GuildOwnerMapping",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "guildId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "ownerId", Type: "uint256"},
+		},
+	},
+	"GuildRequest": {
+		Name:      "GuildRequest",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "guildId", Type: "uint256"},
+			{Name: "requestedAt", Type: "uint256"},
+		},
+	},
+	"HealingItemInfo": {
+		Name:      "HealingItemInfo",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "hpRestore", Type: "uint32"},
+		},
+	},
+	"HistoryCounter": {
+		Name:      "HistoryCounter",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "cityId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "counter", Type: "uint256"},
+		},
+	},
+	"InventoryConfig": {
+		Name:      "InventoryConfig",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "baseWeight", Type: "uint32"},
+			{Name: "maxWeight", Type: "uint32"},
+		},
+	},
+	"InventoryEquipmentIndex": {
+		Name:      "InventoryEquipmentIndex",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "equipmentId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "index", Type: "uint256"},
+		},
+	},
+	"InventoryToolIndex": {
+		Name:      "InventoryToolIndex",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "toolId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "index", Type: "uint256"},
+		},
+	},
+	"ItemRecipeV3": {
+		Name:      "ItemRecipeV3",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "goldCost", Type: "uint32"},
+			{Name: "fameCost", Type: "uint32"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "perkTypes", Type: "uint8[]"},
+			{Name: "requiredPerkLevels", Type: "uint8[]"},
+			{Name: "itemIds", Type: "uint256[]"},
+			{Name: "amounts", Type: "uint32[]"},
+		},
+	},
+	"ItemV2": {
+		Name:      "ItemV2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "category", Type: "uint8", Enum: "ItemCategoryType"},
+			{Name: "itemType", Type: "uint8", Enum: "ItemType"},
+			{Name: "weight", Type: "uint32"},
+			{Name: "tier", Type: "uint8"},
+			{Name: "isUntradeable", Type: "bool"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "name", Type: "string"},
+		},
+	},
+	"ItemWeightCache": {
+		Name:      "ItemWeightCache",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "weight", Type: "uint32"},
+		},
+	},
+	"KingElection": {
+		Name:      "KingElection",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "kingdomId", Type: "uint8"},
+		},
+		Static: []FieldSchema{
+			{Name: "kingId", Type: "uint256"},
+			{Name: "timestamp", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "candidateIds", Type: "uint256[]"},
+			{Name: "votesReceived", Type: "uint32[]"},
+		},
+	},
+	"KingSetting": {
+		Name:      "KingSetting",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "kingdomId", Type: "uint8"},
+		},
+		Static: []FieldSchema{
+			{Name: "pvpFamePenalty", Type: "uint16"},
+			{Name: "captureTilePenalty", Type: "uint16"},
+		},
+	},
+	"KingSetting2": {
+		Name:      "KingSetting2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "kingdomId", Type: "uint8"},
+		},
+		Static: []FieldSchema{
+			{Name: "withdrawWeightLimit", Type: "uint32"},
+		},
+	},
+	"Kingdom": {
+		Name:      "Kingdom",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint8"},
+		},
+		Static: []FieldSchema{
+			{Name: "capitalId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "name", Type: "string"},
+		},
+	},
+	"KingdomCityCounter": {
+		Name:      "KingdomCityCounter",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "kingdomId", Type: "uint8"},
+		},
+		Static: []FieldSchema{
+			{Name: "counter", Type: "uint256"},
+		},
+	},
+	"MapConfig": {
+		Name:      "MapConfig",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "width", Type: "uint32"},
+			{Name: "height", Type: "uint32"},
+		},
+	},
+	"MarketFee": {
+		Name:      "MarketFee",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "kingdomAId", Type: "uint8"},
+			{Name: "kingdomBId", Type: "uint8"},
+		},
+		Static: []FieldSchema{
+			{Name: "fee", Type: "uint8"},
+		},
+	},
+	"Monster": {
+		Name:      "Monster",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "grow", Type: "uint8"},
+			{Name: "exp", Type: "uint32"},
+			{Name: "perkExp", Type: "uint32"},
+			{Name: "isBoss", Type: "bool"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "name", Type: "string"},
+			{Name: "skillIds", Type: "uint256[5]"},
+			{Name: "itemIds", Type: "uint256[]"},
+			{Name: "itemAmounts", Type: "uint32[]"},
+		},
+	},
+	"MonsterIndexLocation": {
+		Name:      "MonsterIndexLocation",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+			{Name: "monsterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "index", Type: "uint256"},
+		},
+	},
+	"MonsterLocation": {
+		Name:      "MonsterLocation",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+			{Name: "monsterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "level", Type: "uint16"},
+			{Name: "advantageType", Type: "uint8", Enum: "AdvantageType"},
+		},
+	},
+	"MonsterStats": {
+		Name:      "MonsterStats",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "monsterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "hp", Type: "uint32"},
+			{Name: "atk", Type: "uint16"},
+			{Name: "def", Type: "uint16"},
+			{Name: "agi", Type: "uint16"},
+			{Name: "sp", Type: "uint8"},
+		},
+	},
+	"MovementConfig": {
+		Name:      "MovementConfig",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "baseMovementSpeed", Type: "uint16"},
+			{Name: "maxMovementSpeed", Type: "uint16"},
+			{Name: "duration", Type: "uint16"},
+		},
+	},
+	"NonOccupyTile": {
+		Name:      "NonOccupyTile",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+		},
+		Static: []FieldSchema{
+			{Name: "value", Type: "bool"},
+		},
+	},
+	"Npc": {
+		Name:      "Npc",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "cityId", Type: "uint256"},
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "name", Type: "string"},
+		},
+	},
+	"NpcShop": {
+		Name:      "NpcShop",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "cityId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "gold", Type: "uint32"},
+		},
+	},
+	"NpcShopInventory": {
+		Name:      "NpcShopInventory",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "cityId", Type: "uint256"},
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "cId", Type: "uint256"},
+			{Name: "amount", Type: "uint32"},
+		},
+	},
+	"Order": {
+		Name:      "Order",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "cityId", Type: "uint256"},
+			{Name: "characterId", Type: "uint256"},
+			{Name: "equipmentId", Type: "uint256"},
+			{Name: "itemId", Type: "uint256"},
+			{Name: "amount", Type: "uint32"},
+			{Name: "unitPrice", Type: "uint32"},
+			{Name: "isBuy", Type: "bool"},
+			{Name: "isDone", Type: "bool"},
+		},
+	},
+	"Order2V2": {
+		Name:      "Order2V2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "currency", Type: "uint8", Enum: "CurrencyType"},
+			{Name: "createdTime", Type: "uint256"},
+			{Name: "updateTime", Type: "uint256"},
+		},
+	},
+	"OrderCounter": {
+		Name:      "OrderCounter",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "counter", Type: "uint256"},
+		},
+	},
+	"PetCpnInfo": {
+		Name:      "PetCpnInfo",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "petId", Type: "uint256"},
+			{Name: "componentType", Type: "uint8", Enum: "PetComponentType"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "componentRatios", Type: "uint16[]"},
+		},
+	},
+	"PetCpnV2": {
+		Name:      "PetCpnV2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "petEquipmentId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "componentValues", Type: "uint16[]"},
+		},
+	},
+	"PvE": {
+		Name:      "PvE",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "monsterId", Type: "uint256"},
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+			{Name: "firstAttacker", Type: "uint8", Enum: "EntityType"},
+			{Name: "counter", Type: "uint256"},
+			{Name: "timestamp", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "characterSkillIds", Type: "uint256[5]"},
+			{Name: "damages", Type: "uint32[11]"},
+			{Name: "hps", Type: "uint32[2]"},
+		},
+	},
+	"PvEAfk": {
+		Name:      "PvEAfk",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "monsterId", Type: "uint256"},
+			{Name: "startTime", Type: "uint256"},
+			{Name: "expPerTick", Type: "uint32"},
+			{Name: "perkExpPerTick", Type: "uint32"},
+			{Name: "maxTick", Type: "uint32"},
+		},
+	},
+	"PvEAfkExpAmp": {
+		Name:      "PvEAfkExpAmp",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "expAmp", Type: "uint32"},
+		},
+	},
+	"PvEAfkLoc": {
+		Name:      "PvEAfkLoc",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+		},
+		Static: []FieldSchema{
+			{Name: "monsterId", Type: "uint256"},
+		},
+	},
+	"PvEExtraV2": {
+		Name:      "PvEExtraV2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+			{Name: "itemAmount", Type: "uint32"},
+			{Name: "characterBarrier", Type: "uint32"},
+		},
+	},
+	"PvP": {
+		Name:      "PvP",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "attackerId", Type: "uint256"},
+			{Name: "defenderId", Type: "uint256"},
+			{Name: "firstAttackerId", Type: "uint256"},
+			{Name: "timestamp", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "prevPvpIds", Type: "uint256[2]"},
+			{Name: "skillIds", Type: "uint256[11]"},
+			{Name: "damages", Type: "uint32[11]"},
+			{Name: "hps", Type: "uint32[2]"},
+		},
+	},
+	"PvPBattleCounter": {
+		Name:      "PvPBattleCounter",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "counter", Type: "uint256"},
+		},
+	},
+	"PvPChallengeV2": {
+		Name:      "PvPChallengeV2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "attackerId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "defenderId", Type: "uint256"},
+			{Name: "firstAttackerId", Type: "uint256"},
+			{Name: "timestamp", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "skillIds", Type: "uint256[11]"},
+			{Name: "damages", Type: "uint32[11]"},
+			{Name: "hps", Type: "uint32[2]"},
+			{Name: "barriers", Type: "uint32[2]"},
+		},
+	},
+	"PvPEnemyCounter": {
+		Name:      "PvPEnemyCounter",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "counter", Type: "uint256"},
+		},
+	},
+	"PvPExtra2V3": {
+		Name:      "PvPExtra2V3",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "pvpId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "attackerStats", Type: "uint16[3]"},
+			{Name: "defenderStats", Type: "uint16[3]"},
+			{Name: "attackerBuffs", Type: "uint256[4]"},
+			{Name: "defenderBuffs", Type: "uint256[4]"},
+		},
+	},
+	"PvPExtraV3": {
+		Name:      "PvPExtraV3",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "pvpId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "characterLevels", Type: "uint16[2]"},
+			{Name: "characterSps", Type: "uint8[2]"},
+			{Name: "barriers", Type: "uint32[2]"},
+			{Name: "fames", Type: "int32[2]"},
+			{Name: "equipmentIds", Type: "uint256[12]"},
+		},
+	},
+	"QuestContribute": {
+		Name:      "QuestContribute",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "questId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "itemIds", Type: "uint256[]"},
+			{Name: "amounts", Type: "uint32[]"},
+		},
+	},
+	"QuestLocate": {
+		Name:      "QuestLocate",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "questId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "xs", Type: "int32[]"},
+			{Name: "ys", Type: "int32[]"},
+		},
+	},
+	"QuestLocateTracking2": {
+		Name:      "QuestLocateTracking2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "questId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "trackIndex", Type: "uint8"},
+		},
+	},
+	"QuestV4": {
+		Name:      "QuestV4",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "exp", Type: "uint32"},
+			{Name: "gold", Type: "uint32"},
+			{Name: "questType", Type: "uint8", Enum: "QuestType"},
+			{Name: "fromNpcId", Type: "uint256"},
+			{Name: "toNpcId", Type: "uint256"},
+			{Name: "achievementId", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "requiredAchievementIds", Type: "uint256[]"},
+			{Name: "requiredDoneQuestIds", Type: "uint256[]"},
+			{Name: "rewardItemIds", Type: "uint256[]"},
+			{Name: "rewardItemAmounts", Type: "uint32[]"},
+		},
+	},
+	"ResourceInfo": {
+		Name:      "ResourceInfo",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "resourceType", Type: "uint8", Enum: "ResourceType"},
+		},
+	},
+	"RestrictLocV2": {
+		Name:      "RestrictLocV2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+		},
+		Static: []FieldSchema{
+			{Name: "cityId", Type: "uint256"},
+			{Name: "isRestricted", Type: "bool"},
+		},
+	},
+	"RestrictLocation": {
+		Name:      "RestrictLocation",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+		},
+		Static: []FieldSchema{
+			{Name: "isRestricted", Type: "bool"},
+		},
+	},
+	"SalePackageV2": {
+		Name:      "SalePackageV2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "crystalPrice", Type: "uint32"},
+			{Name: "goldPrice", Type: "uint32"},
+			{Name: "gold", Type: "uint32"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "achievementIds", Type: "uint256[]"},
+			{Name: "itemIds", Type: "uint256[]"},
+			{Name: "itemAmounts", Type: "uint32[]"},
+		},
+	},
+	"SellCrystalCounter": {
+		Name:      "SellCrystalCounter",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "count", Type: "uint256"},
+		},
+	},
+	"SellCrystalReq": {
+		Name:      "SellCrystalReq",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "amount", Type: "uint32"},
+			{Name: "isDone", Type: "bool"},
+			{Name: "requestedAt", Type: "uint256"},
+		},
+	},
+	"SkillEffect": {
+		Name:      "SkillEffect",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "effect", Type: "uint8", Enum: "EffectType"},
+			{Name: "damage", Type: "uint16"},
+			{Name: "turns", Type: "uint8"},
+		},
+	},
+	"SkillV2": {
+		Name:      "SkillV2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "sp", Type: "uint8"},
+			{Name: "damage", Type: "uint16"},
+			{Name: "hasEffect", Type: "bool"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "name", Type: "string"},
+			{Name: "perkItemTypes", Type: "uint8[]"},
+			{Name: "requiredPerkLevels", Type: "uint8[]"},
+		},
+	},
+	"SkinInfo": {
+		Name:      "SkinInfo",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "slotType", Type: "uint8", Enum: "SkinSlotType"},
+		},
+	},
+	"StatModifierItemInfo": {
+		Name:      "StatModifierItemInfo",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "duration", Type: "uint16"},
+			{Name: "atkPercent", Type: "int16"},
+			{Name: "defPercent", Type: "int16"},
+			{Name: "agiPercent", Type: "int16"},
+			{Name: "ms", Type: "int16"},
+		},
+	},
+	"StorageEquipmentIndex": {
+		Name:      "StorageEquipmentIndex",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "cityId", Type: "uint256"},
+			{Name: "equipmentId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "index", Type: "uint256"},
+		},
+	},
+	"StorageToolIndex": {
+		Name:      "StorageToolIndex",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+			{Name: "cityId", Type: "uint256"},
+			{Name: "toolId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "index", Type: "uint256"},
+		},
+	},
+	"TestTable": {
+		Name:      "TestTable",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "column1", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "column2", Type: "uint256"},
+			{Name: "column3", Type: "uint256"},
+		},
+	},
+	"TileEquipmentIndex": {
+		Name:      "TileEquipmentIndex",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+			{Name: "equipmentId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "index", Type: "uint256"},
+		},
+	},
+	"TileInfo3": {
+		Name:      "TileInfo3",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+		},
+		Static: []FieldSchema{
+			{Name: "kingdomId", Type: "uint8"},
+			{Name: "farmSlot", Type: "uint8"},
+			{Name: "zoneType", Type: "uint8", Enum: "ZoneType"},
+			{Name: "occupiedTime", Type: "uint256"},
+			{Name: "replenishTime", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "itemIds", Type: "uint256[]"},
+			{Name: "farmingQuotas", Type: "uint16[]"},
+			{Name: "monsterIds", Type: "uint256[]"},
+		},
+	},
+	"TileInventory": {
+		Name:      "TileInventory",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+		},
+		Static: []FieldSchema{
+			{Name: "lastDropTime", Type: "uint256"},
+		},
+		Dynamic: []FieldSchema{
+			{Name: "equipmentIds", Type: "uint256[]"},
+			{Name: "toolIds", Type: "uint256[]"},
+			{Name: "otherItemIds", Type: "uint256[]"},
+			{Name: "otherItemAmounts", Type: "uint32[]"},
+		},
+	},
+	"TileOtherItemIndex": {
+		Name:      "TileOtherItemIndex",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "index", Type: "uint256"},
+		},
+	},
+	"Tool2": {
+		Name:      "Tool2",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "id", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "itemId", Type: "uint256"},
+			{Name: "characterId", Type: "uint256"},
+			{Name: "durability", Type: "uint16"},
+		},
+	},
+	"ToolSupply": {
+		Name:      "ToolSupply",
+		Namespace: "app",
+		Static: []FieldSchema{
+			{Name: "totalSupply", Type: "uint256"},
+		},
+	},
+	"Unmovable": {
+		Name:      "Unmovable",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "x", Type: "int32"},
+			{Name: "y", Type: "int32"},
+		},
+		Static: []FieldSchema{
+			{Name: "value", Type: "bool"},
+		},
+	},
+	"VaultRestriction": {
+		Name:      "VaultRestriction",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "kingdomId", Type: "uint8"},
+			{Name: "itemId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "isRestricted", Type: "bool"},
+		},
+	},
+	"WelcomeConfig": {
+		Name:      "WelcomeConfig",
+		Namespace: "app",
+		Dynamic: []FieldSchema{
+			{Name: "itemDetailIds", Type: "uint256[]"},
+		},
+	},
+	"WelcomePackages": {
+		Name:      "WelcomePackages",
+		Namespace: "app",
+		Key: []FieldSchema{
+			{Name: "characterId", Type: "uint256"},
+		},
+		Static: []FieldSchema{
+			{Name: "claimed", Type: "bool"},
+		},
+	},
+}
@@ -1,7 +1,9 @@
 package mud
 
 import (
+	"fmt"
 	"log"
+	"math/big"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -104,6 +106,179 @@ var contractABI = `
     ],
     "outputs": [],
     "stateMutability": "nonpayable"
+  },
+  {
+    "type": "function",
+    "name": "deleteRecord",
+    "inputs": [
+      {
+        "name": "tableId",
+        "type": "bytes32",
+        "internalType": "ResourceId"
+      },
+      {
+        "name": "keyTuple",
+        "type": "bytes32[]",
+        "internalType": "bytes32[]"
+      }
+    ],
+    "outputs": [],
+    "stateMutability": "nonpayable"
+  },
+  {
+    "type": "function",
+    "name": "spliceStaticData",
+    "inputs": [
+      {
+        "name": "tableId",
+        "type": "bytes32",
+        "internalType": "ResourceId"
+      },
+      {
+        "name": "keyTuple",
+        "type": "bytes32[]",
+        "internalType": "bytes32[]"
+      },
+      {
+        "name": "start",
+        "type": "uint48",
+        "internalType": "uint48"
+      },
+      {
+        "name": "data",
+        "type": "bytes",
+        "internalType": "bytes"
+      }
+    ],
+    "outputs": [],
+    "stateMutability": "nonpayable"
+  },
+  {
+    "type": "function",
+    "name": "spliceDynamicData",
+    "inputs": [
+      {
+        "name": "tableId",
+        "type": "bytes32",
+        "internalType": "ResourceId"
+      },
+      {
+        "name": "keyTuple",
+        "type": "bytes32[]",
+        "internalType": "bytes32[]"
+      },
+      {
+        "name": "dynamicFieldIndex",
+        "type": "uint8",
+        "internalType": "uint8"
+      },
+      {
+        "name": "startWithinField",
+        "type": "uint40",
+        "internalType": "uint40"
+      },
+      {
+        "name": "deleteCount",
+        "type": "uint40",
+        "internalType": "uint40"
+      },
+      {
+        "name": "data",
+        "type": "bytes",
+        "internalType": "bytes"
+      }
+    ],
+    "outputs": [],
+    "stateMutability": "nonpayable"
+  },
+  {
+    "type": "function",
+    "name": "pushToDynamicField",
+    "inputs": [
+      {
+        "name": "tableId",
+        "type": "bytes32",
+        "internalType": "ResourceId"
+      },
+      {
+        "name": "keyTuple",
+        "type": "bytes32[]",
+        "internalType": "bytes32[]"
+      },
+      {
+        "name": "dynamicFieldIndex",
+        "type": "uint8",
+        "internalType": "uint8"
+      },
+      {
+        "name": "dataToPush",
+        "type": "bytes",
+        "internalType": "bytes"
+      }
+    ],
+    "outputs": [],
+    "stateMutability": "nonpayable"
+  },
+  {
+    "type": "function",
+    "name": "popFromDynamicField",
+    "inputs": [
+      {
+        "name": "tableId",
+        "type": "bytes32",
+        "internalType": "ResourceId"
+      },
+      {
+        "name": "keyTuple",
+        "type": "bytes32[]",
+        "internalType": "bytes32[]"
+      },
+      {
+        "name": "dynamicFieldIndex",
+        "type": "uint8",
+        "internalType": "uint8"
+      },
+      {
+        "name": "byteLengthToPop",
+        "type": "uint256",
+        "internalType": "uint256"
+      }
+    ],
+    "outputs": [],
+    "stateMutability": "nonpayable"
+  },
+  {
+    "type": "function",
+    "name": "setField",
+    "inputs": [
+      {
+        "name": "tableId",
+        "type": "bytes32",
+        "internalType": "ResourceId"
+      },
+      {
+        "name": "keyTuple",
+        "type": "bytes32[]",
+        "internalType": "bytes32[]"
+      },
+      {
+        "name": "fieldIndex",
+        "type": "uint8",
+        "internalType": "uint8"
+      },
+      {
+        "name": "data",
+        "type": "bytes",
+        "internalType": "bytes"
+      },
+      {
+        "name": "fieldLayout",
+        "type": "bytes32",
+        "internalType": "FieldLayout"
+      }
+    ],
+    "outputs": [],
+    "stateMutability": "nonpayable"
   }
 ]
 `
@@ -114,20 +289,35 @@ type MudTable struct {
 	Namespace   string
 	FieldLayout FieldLayout
 	TableID     ResourceId
+	Schema      TableSchema
 	abi         abi.ABI
 }
 
-// New creates a new MudTable instance
+// New creates a new MudTable instance.
+// An empty fieldLayout means the layout is computed from the table schema.
 func NewMudTable(tableName, namespace, fieldLayout string) MudTable {
 	abi, err := abi.JSON(strings.NewReader(contractABI))
 	if err != nil {
 		log.Fatalf("failed to parse ABI: %v", err)
 	}
+	schema := Tables[tableName]
+	fl := getFieldLayout(fieldLayout)
+	if fieldLayout == "" && schema.Name != "" {
+		fl, err = EncodeFieldLayout(schema)
+		if err != nil {
+			log.Fatalf("failed to compute field layout: %v", err)
+		}
+	}
+	tableId, err := getTableId(tableName, namespace)
+	if err != nil {
+		log.Fatalf("invalid table id: %v", err)
+	}
 	return MudTable{
 		TableName:   tableName,
 		Namespace:   namespace,
-		FieldLayout: getFieldLayout(fieldLayout),
-		TableID:     getTableId(tableName, namespace),
+		FieldLayout: fl,
+		TableID:     tableId,
+		Schema:      schema,
 		abi:         abi,
 	}
 }
@@ -139,6 +329,10 @@ func (mt *MudTable) SetRecordRawCalldata(
 	encodedLength PackedCounter,
 	dynamicData []byte,
 ) ([]byte, error) {
+	if err := mt.checkRecord(keyTuple, staticData, encodedLength, dynamicData); err != nil {
+		zap.S().Errorw("invalid setRecord data", "table", mt.TableName, "err", err)
+		return nil, err
+	}
 	callData, err := mt.abi.Pack("setRecord", mt.TableID, keyTuple, staticData, encodedLength, dynamicData)
 	if err != nil {
 		zap.S().Errorw("cannot pack data setRecord", "err", err)
@@ -153,6 +347,10 @@ func (mt *MudTable) SetDynamicFieldRawCalldata(
 	dynamicFieldIndex uint8,
 	dynamicData []byte,
 ) ([]byte, error) {
+	if err := mt.checkDynamicField(keyTuple, int(dynamicFieldIndex), dynamicData); err != nil {
+		zap.S().Errorw("invalid setDynamicField data", "table", mt.TableName, "err", err)
+		return nil, err
+	}
 	callData, err := mt.abi.Pack("setDynamicField", mt.TableID, keyTuple, dynamicFieldIndex, dynamicData)
 	if err != nil {
 		zap.S().Errorw("cannot pack data setDynamicField", "err", err)
@@ -161,12 +359,16 @@ func (mt *MudTable) SetDynamicFieldRawCalldata(
 	return callData, nil
 }
 
-// SetDynamicFieldRawCalldata returns raw calldata of setDynamicField
+// SetStaticFieldRawCalldata returns raw calldata of setStaticField
 func (mt *MudTable) SetStaticFieldRawCalldata(
 	keyTuple [][32]byte,
 	staticFieldIndex int,
 	staticData []byte,
 ) ([]byte, error) {
+	if err := mt.checkStaticField(keyTuple, staticFieldIndex, staticData); err != nil {
+		zap.S().Errorw("invalid setStaticField data", "table", mt.TableName, "err", err)
+		return nil, err
+	}
 	callData, err := mt.abi.Pack("setStaticField", mt.TableID, keyTuple, uint8(staticFieldIndex), staticData, mt.FieldLayout)
 	if err != nil {
 		zap.S().Errorw("cannot pack data setStaticField", "err", err)
@@ -174,3 +376,209 @@ func (mt *MudTable) SetStaticFieldRawCalldata(
 	}
 	return callData, nil
 }
+
+// DeleteRecordRawCalldata returns raw calldata of deleteRecord
+func (mt *MudTable) DeleteRecordRawCalldata(keyTuple [][32]byte) ([]byte, error) {
+	if err := mt.checkKeyTuple(keyTuple); err != nil {
+		zap.S().Errorw("invalid deleteRecord data", "table", mt.TableName, "err", err)
+		return nil, err
+	}
+	callData, err := mt.abi.Pack("deleteRecord", mt.TableID, keyTuple)
+	if err != nil {
+		zap.S().Errorw("cannot pack data deleteRecord", "err", err)
+		return nil, err
+	}
+	return callData, nil
+}
+
+// SpliceStaticDataRawCalldata returns raw calldata of spliceStaticData,
+// start is the byte offset within the static data of the record
+func (mt *MudTable) SpliceStaticDataRawCalldata(
+	keyTuple [][32]byte,
+	start uint64,
+	data []byte,
+) ([]byte, error) {
+	if err := mt.checkKeyTuple(keyTuple); err != nil {
+		zap.S().Errorw("invalid spliceStaticData data", "table", mt.TableName, "err", err)
+		return nil, err
+	}
+	if start+uint64(len(data)) > uint64(mt.Schema.StaticLength()) {
+		err := fmt.Errorf("table %s: splice [%d, %d) out of static length %d",
+			mt.TableName, start, start+uint64(len(data)), mt.Schema.StaticLength())
+		zap.S().Errorw("invalid spliceStaticData data", "table", mt.TableName, "err", err)
+		return nil, err
+	}
+	callData, err := mt.abi.Pack("spliceStaticData", mt.TableID, keyTuple, new(big.Int).SetUint64(start), data)
+	if err != nil {
+		zap.S().Errorw("cannot pack data spliceStaticData", "err", err)
+		return nil, err
+	}
+	return callData, nil
+}
+
+// SpliceDynamicDataRawCalldata returns raw calldata of spliceDynamicData,
+// startWithinField and deleteCount are byte offsets within the dynamic field
+func (mt *MudTable) SpliceDynamicDataRawCalldata(
+	keyTuple [][32]byte,
+	dynamicFieldIndex uint8,
+	startWithinField uint64,
+	deleteCount uint64,
+	data []byte,
+) ([]byte, error) {
+	if err := mt.checkDynamicField(keyTuple, int(dynamicFieldIndex), data); err != nil {
+		zap.S().Errorw("invalid spliceDynamicData data", "table", mt.TableName, "err", err)
+		return nil, err
+	}
+	elemSize := uint64(DynamicElementLength(mt.Schema.Dynamic[dynamicFieldIndex].Type))
+	if startWithinField%elemSize != 0 || deleteCount%elemSize != 0 {
+		err := fmt.Errorf("table %s: splice is not aligned to element size %d", mt.TableName, elemSize)
+		zap.S().Errorw("invalid spliceDynamicData data", "table", mt.TableName, "err", err)
+		return nil, err
+	}
+	callData, err := mt.abi.Pack("spliceDynamicData", mt.TableID, keyTuple, dynamicFieldIndex,
+		new(big.Int).SetUint64(startWithinField), new(big.Int).SetUint64(deleteCount), data)
+	if err != nil {
+		zap.S().Errorw("cannot pack data spliceDynamicData", "err", err)
+		return nil, err
+	}
+	return callData, nil
+}
+
+// PushToDynamicFieldRawCalldata returns raw calldata of pushToDynamicField
+func (mt *MudTable) PushToDynamicFieldRawCalldata(
+	keyTuple [][32]byte,
+	dynamicFieldIndex uint8,
+	dataToPush []byte,
+) ([]byte, error) {
+	if err := mt.checkDynamicField(keyTuple, int(dynamicFieldIndex), dataToPush); err != nil {
+		zap.S().Errorw("invalid pushToDynamicField data", "table", mt.TableName, "err", err)
+		return nil, err
+	}
+	callData, err := mt.abi.Pack("pushToDynamicField", mt.TableID, keyTuple, dynamicFieldIndex, dataToPush)
+	if err != nil {
+		zap.S().Errorw("cannot pack data pushToDynamicField", "err", err)
+		return nil, err
+	}
+	return callData, nil
+}
+
+// PopFromDynamicFieldRawCalldata returns raw calldata of popFromDynamicField
+func (mt *MudTable) PopFromDynamicFieldRawCalldata(
+	keyTuple [][32]byte,
+	dynamicFieldIndex uint8,
+	byteLengthToPop uint64,
+) ([]byte, error) {
+	if err := mt.checkKeyTuple(keyTuple); err != nil {
+		zap.S().Errorw("invalid popFromDynamicField data", "table", mt.TableName, "err", err)
+		return nil, err
+	}
+	if int(dynamicFieldIndex) >= len(mt.Schema.Dynamic) {
+		err := fmt.Errorf("table %s: dynamic field index %d out of range", mt.TableName, dynamicFieldIndex)
+		zap.S().Errorw("invalid popFromDynamicField data", "table", mt.TableName, "err", err)
+		return nil, err
+	}
+	if elemSize := uint64(DynamicElementLength(mt.Schema.Dynamic[dynamicFieldIndex].Type)); byteLengthToPop%elemSize != 0 {
+		err := fmt.Errorf("table %s: pop length %d is not a multiple of %d", mt.TableName, byteLengthToPop, elemSize)
+		zap.S().Errorw("invalid popFromDynamicField data", "table", mt.TableName, "err", err)
+		return nil, err
+	}
+	callData, err := mt.abi.Pack("popFromDynamicField", mt.TableID, keyTuple, dynamicFieldIndex,
+		new(big.Int).SetUint64(byteLengthToPop))
+	if err != nil {
+		zap.S().Errorw("cannot pack data popFromDynamicField", "err", err)
+		return nil, err
+	}
+	return callData, nil
+}
+
+// SetFieldRawCalldata returns raw calldata of setField.
+// fieldIndex counts static fields first, then dynamic fields.
+func (mt *MudTable) SetFieldRawCalldata(
+	keyTuple [][32]byte,
+	fieldIndex int,
+	data []byte,
+) ([]byte, error) {
+	var err error
+	if fieldIndex < len(mt.Schema.Static) {
+		err = mt.checkStaticField(keyTuple, fieldIndex, data)
+	} else {
+		err = mt.checkDynamicField(keyTuple, fieldIndex-len(mt.Schema.Static), data)
+	}
+	if err != nil {
+		zap.S().Errorw("invalid setField data", "table", mt.TableName, "err", err)
+		return nil, err
+	}
+	callData, err := mt.abi.Pack("setField", mt.TableID, keyTuple, uint8(fieldIndex), data, mt.FieldLayout)
+	if err != nil {
+		zap.S().Errorw("cannot pack data setField", "err", err)
+		return nil, err
+	}
+	return callData, nil
+}
+
+// UnpackSetRecord returns the arguments of a setRecord calldata
+func UnpackSetRecord(callData []byte) (
+	tableId ResourceId,
+	keyTuple [][32]byte,
+	staticData []byte,
+	encodedLengths PackedCounter,
+	dynamicData []byte,
+	err error,
+) {
+	method, args, err := unpackCall(callData)
+	if err != nil {
+		return tableId, nil, nil, encodedLengths, nil, err
+	}
+	if method.Name != "setRecord" {
+		return tableId, nil, nil, encodedLengths, nil, fmt.Errorf("unexpected method %s", method.Name)
+	}
+	return args[0].([32]byte), args[1].([][32]byte), args[2].([]byte), args[3].([32]byte), args[4].([]byte), nil
+}
+
+func (mt *MudTable) checkKeyTuple(keyTuple [][32]byte) error {
+	if mt.Schema.Name == "" {
+		return fmt.Errorf("table %s has no schema", mt.TableName)
+	}
+	if len(keyTuple) != len(mt.Schema.Key) {
+		return fmt.Errorf("table %s: got %d keys, schema has %d", mt.TableName, len(keyTuple), len(mt.Schema.Key))
+	}
+	return nil
+}
+
+// checkRecord validates a full record against the table schema
+func (mt *MudTable) checkRecord(keyTuple [][32]byte, staticData []byte, encodedLength PackedCounter, dynamicData []byte) error {
+	if err := mt.checkKeyTuple(keyTuple); err != nil {
+		return err
+	}
+	if len(staticData) != mt.Schema.StaticLength() {
+		return fmt.Errorf("table %s: static data length %d, schema length %d",
+			mt.TableName, len(staticData), mt.Schema.StaticLength())
+	}
+	_, err := mt.Schema.DecodeDynamicData(encodedLength, dynamicData)
+	return err
+}
+
+func (mt *MudTable) checkStaticField(keyTuple [][32]byte, index int, data []byte) error {
+	if err := mt.checkKeyTuple(keyTuple); err != nil {
+		return err
+	}
+	if index < 0 || index >= len(mt.Schema.Static) {
+		return fmt.Errorf("table %s: static field index %d out of range", mt.TableName, index)
+	}
+	if size := StaticByteLength(mt.Schema.Static[index].Type); len(data) != size {
+		return fmt.Errorf("table %s: field %s length %d, expected %d",
+			mt.TableName, mt.Schema.Static[index].Name, len(data), size)
+	}
+	return nil
+}
+
+func (mt *MudTable) checkDynamicField(keyTuple [][32]byte, index int, data []byte) error {
+	if err := mt.checkKeyTuple(keyTuple); err != nil {
+		return err
+	}
+	if index < 0 || index >= len(mt.Schema.Dynamic) {
+		return fmt.Errorf("table %s: dynamic field index %d out of range", mt.TableName, index)
+	}
+	_, err := DecodeDynamicValue(mt.Schema.Dynamic[index].Type, data)
+	return err
+}
@@ -0,0 +1,144 @@
+package mud
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// abiWord left pads a non-negative integer to one 32 byte ABI word
+func abiWord(v int64) []byte {
+	return math.U256Bytes(big.NewInt(v))
+}
+
+// abiBytes encodes a bytes tail: length word followed by right padded data
+func abiBytes(data []byte) []byte {
+	padded := make([]byte, (len(data)+31)/32*32)
+	copy(padded, data)
+	return append(abiWord(int64(len(data))), padded...)
+}
+
+// expectedCall assembles calldata by hand so the tests do not depend on the embedded ABI json
+func expectedCall(signature string, words ...[]byte) []byte {
+	return append(crypto.Keccak256([]byte(signature))[:4], bytes.Join(words, nil)...)
+}
+
+func TestWorldWriteCalldata(t *testing.T) {
+	mt := NewMudTable("TileInfo3", "app", "")
+	keyTuple := [][32]byte{
+		[32]byte(math.U256Bytes(big.NewInt(-3))),
+		[32]byte(math.U256Bytes(big.NewInt(4))),
+	}
+	// the key tuple tail is the same for every call
+	keys := bytes.Join([][]byte{abiWord(2), keyTuple[0][:], keyTuple[1][:]}, nil)
+	tableId := mt.TableID[:]
+
+	t.Run("deleteRecord", func(t *testing.T) {
+		callData, err := mt.DeleteRecordRawCalldata(keyTuple)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(expectedCall("deleteRecord(bytes32,bytes32[])",
+			tableId, abiWord(64), keys)), hex.EncodeToString(callData))
+	})
+
+	t.Run("spliceStaticData", func(t *testing.T) {
+		// zoneType is the 3rd uint8 of the static data
+		callData, err := mt.SpliceStaticDataRawCalldata(keyTuple, 2, []byte{1})
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(expectedCall("spliceStaticData(bytes32,bytes32[],uint48,bytes)",
+			tableId, abiWord(128), abiWord(2), abiWord(224), keys, abiBytes([]byte{1}))), hex.EncodeToString(callData))
+
+		_, err = mt.SpliceStaticDataRawCalldata(keyTuple, 67, []byte{1})
+		require.Error(t, err)
+	})
+
+	t.Run("spliceDynamicData", func(t *testing.T) {
+		data := abiWord(9)
+		callData, err := mt.SpliceDynamicDataRawCalldata(keyTuple, 0, 32, 32, data)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(expectedCall("spliceDynamicData(bytes32,bytes32[],uint8,uint40,uint40,bytes)",
+			tableId, abiWord(192), abiWord(0), abiWord(32), abiWord(32), abiWord(288), keys, abiBytes(data))),
+			hex.EncodeToString(callData))
+
+		_, err = mt.SpliceDynamicDataRawCalldata(keyTuple, 0, 1, 0, data)
+		require.Error(t, err)
+	})
+
+	t.Run("pushToDynamicField", func(t *testing.T) {
+		data := []byte{0, 5}
+		callData, err := mt.PushToDynamicFieldRawCalldata(keyTuple, 1, data)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(expectedCall("pushToDynamicField(bytes32,bytes32[],uint8,bytes)",
+			tableId, abiWord(128), abiWord(1), abiWord(224), keys, abiBytes(data))), hex.EncodeToString(callData))
+
+		_, err = mt.PushToDynamicFieldRawCalldata(keyTuple, 1, []byte{5})
+		require.Error(t, err)
+	})
+
+	t.Run("popFromDynamicField", func(t *testing.T) {
+		callData, err := mt.PopFromDynamicFieldRawCalldata(keyTuple, 2, 64)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(expectedCall("popFromDynamicField(bytes32,bytes32[],uint8,uint256)",
+			tableId, abiWord(128), abiWord(2), abiWord(64), keys)), hex.EncodeToString(callData))
+
+		_, err = mt.PopFromDynamicFieldRawCalldata(keyTuple, 3, 32)
+		require.Error(t, err)
+	})
+
+	t.Run("setField", func(t *testing.T) {
+		// field index 5 is the first dynamic field (itemIds)
+		data := abiWord(7)
+		callData, err := mt.SetFieldRawCalldata(keyTuple, 5, data)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(expectedCall("setField(bytes32,bytes32[],uint8,bytes,bytes32)",
+			tableId, abiWord(160), abiWord(5), abiWord(256), mt.FieldLayout[:], keys, abiBytes(data))),
+			hex.EncodeToString(callData))
+
+		_, err = mt.SetFieldRawCalldata(keyTuple, 0, []byte{1, 2})
+		require.Error(t, err)
+	})
+}
+
+func TestDecodeWorldWriteCalldata(t *testing.T) {
+	mt := NewMudTable("TileInfo3", "app", "")
+	keyTuple := [][32]byte{
+		[32]byte(math.U256Bytes(big.NewInt(-3))),
+		[32]byte(math.U256Bytes(big.NewInt(4))),
+	}
+	build := []struct {
+		callData func() ([]byte, error)
+		want     string
+	}{
+		{
+			callData: func() ([]byte, error) { return mt.DeleteRecordRawCalldata(keyTuple) },
+			want:     "deleteRecord app:TileInfo3 (x=-3, y=4)",
+		},
+		{
+			callData: func() ([]byte, error) { return mt.SpliceStaticDataRawCalldata(keyTuple, 2, []byte{1}) },
+			want:     "spliceStaticData app:TileInfo3 (x=-3, y=4) zoneType=Orange(1)",
+		},
+		{
+			callData: func() ([]byte, error) { return mt.PushToDynamicFieldRawCalldata(keyTuple, 1, []byte{0, 5}) },
+			want:     "pushToDynamicField app:TileInfo3 (x=-3, y=4) farmingQuotas=[5]",
+		},
+		{
+			callData: func() ([]byte, error) { return mt.PopFromDynamicFieldRawCalldata(keyTuple, 2, 64) },
+			want:     "popFromDynamicField app:TileInfo3 (x=-3, y=4) byteLengthToPop=64 field=monsterIds",
+		},
+		{
+			callData: func() ([]byte, error) { return mt.SetFieldRawCalldata(keyTuple, 0, []byte{3}) },
+			want:     "setField app:TileInfo3 (x=-3, y=4) kingdomId=3",
+		},
+	}
+	for _, tc := range build {
+		callData, err := tc.callData()
+		require.NoError(t, err)
+		decoded, err := DecodeCalldata(callData)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, decoded.String())
+	}
+}
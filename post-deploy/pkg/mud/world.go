@@ -0,0 +1,138 @@
+package mud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// World functions other than the store ones, ABI is in out/IBaseWorld.sol/IBaseWorld.abi.json
+var worldABI = `
+[
+  {
+    "type": "function",
+    "name": "batchCall",
+    "inputs": [
+      {
+        "name": "systemCalls",
+        "type": "tuple[]",
+        "internalType": "struct SystemCallData[]",
+        "components": [
+          {
+            "name": "systemId",
+            "type": "bytes32",
+            "internalType": "ResourceId"
+          },
+          {
+            "name": "callData",
+            "type": "bytes",
+            "internalType": "bytes"
+          }
+        ]
+      }
+    ],
+    "outputs": [
+      {
+        "name": "returnDatas",
+        "type": "bytes[]",
+        "internalType": "bytes[]"
+      }
+    ],
+    "stateMutability": "nonpayable"
+  },
+  {
+    "type": "function",
+    "name": "call",
+    "inputs": [
+      {
+        "name": "systemId",
+        "type": "bytes32",
+        "internalType": "ResourceId"
+      },
+      {
+        "name": "callData",
+        "type": "bytes",
+        "internalType": "bytes"
+      }
+    ],
+    "outputs": [
+      {
+        "name": "",
+        "type": "bytes",
+        "internalType": "bytes"
+      }
+    ],
+    "stateMutability": "payable"
+  },
+  {
+    "type": "function",
+    "name": "grantAccess",
+    "inputs": [
+      {
+        "name": "resourceId",
+        "type": "bytes32",
+        "internalType": "ResourceId"
+      },
+      {
+        "name": "grantee",
+        "type": "address",
+        "internalType": "address"
+      }
+    ],
+    "outputs": [],
+    "stateMutability": "nonpayable"
+  },
+  {
+    "type": "function",
+    "name": "revokeAccess",
+    "inputs": [
+      {
+        "name": "resourceId",
+        "type": "bytes32",
+        "internalType": "ResourceId"
+      },
+      {
+        "name": "grantee",
+        "type": "address",
+        "internalType": "address"
+      }
+    ],
+    "outputs": [],
+    "stateMutability": "nonpayable"
+  }
+]
+`
+
+func packWorldCall(method string, args ...interface{}) ([]byte, error) {
+	contract, err := abi.JSON(strings.NewReader(worldABI))
+	if err != nil {
+		return nil, err
+	}
+	callData, err := contract.Pack(method, args...)
+	if err != nil {
+		zap.S().Errorw("cannot pack data "+method, "err", err)
+		return nil, err
+	}
+	return callData, nil
+}
+
+// CallSystemCalldata returns raw calldata of World.call, callData is the system function call
+func CallSystemCalldata(systemId ResourceId, callData []byte) ([]byte, error) {
+	if systemId.Type() != RESOURCE_SYSTEM {
+		return nil, fmt.Errorf("%s is not a system", systemId)
+	}
+	return packWorldCall("call", systemId, callData)
+}
+
+// GrantAccessCalldata returns raw calldata of World.grantAccess
+func GrantAccessCalldata(resourceId ResourceId, grantee common.Address) ([]byte, error) {
+	return packWorldCall("grantAccess", resourceId, grantee)
+}
+
+// RevokeAccessCalldata returns raw calldata of World.revokeAccess
+func RevokeAccessCalldata(resourceId ResourceId, grantee common.Address) ([]byte, error) {
+	return packWorldCall("revokeAccess", resourceId, grantee)
+}
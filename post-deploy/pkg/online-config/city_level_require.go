@@ -0,0 +1,59 @@
+package onlineconfig
+
+import (
+	"strings"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"go.uber.org/zap"
+)
+
+func getCityLevelRequireUpdate(sheetName string, dataConfig *common.DataConfig) ([]common.CityLevelRequire, error) {
+	l := zap.S().With("func", "getCityLevelRequireUpdate")
+	rawData, err := getSheetRawData(sheetName)
+	if err != nil {
+		l.Errorw("cannot csv reader", "err", err)
+		return nil, err
+	}
+	result := make([]common.CityLevelRequire, 0)
+	var (
+		// level	resources
+		levelIndex, resourcesIndex int
+		headerFound                bool
+	)
+	for i := range rawData {
+		record := rawData[i]
+		if len(record) == 0 {
+			continue
+		}
+		if record[0] == "" { // empty row
+			l.Warnw("invalid city level require format", "data", record)
+			continue
+		}
+		if strings.EqualFold(record[0], "level") { // header
+			if headerFound {
+				l.Panicw("detect header more than one time", "value", record)
+			}
+			levelIndex = 0
+			resourcesIndex = findIndex(record, "resources")
+			l.Infow(
+				"list index",
+				"levelIndex", levelIndex,
+				"resourcesIndex", resourcesIndex,
+			)
+			headerFound = true
+			continue
+		}
+		if !headerFound {
+			l.Panicw("invalid city level require format, header must appear before data rows", "data", record)
+		}
+		if !isNumber(record[levelIndex]) {
+			break // the data part is ended
+		}
+		// resources are Resource items, e.g. "Oak Log - 100\nCopper Ore - 50"
+		result = append(result, common.CityLevelRequire{
+			Level:     uint8(mustStringToInt(record[levelIndex], levelIndex)),
+			Resources: getMaterialList(record, record[resourcesIndex], dataConfig),
+		})
+	}
+	return result, nil
+}
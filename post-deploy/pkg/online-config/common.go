@@ -224,6 +224,25 @@ func findIndex(arr []string, element string) int {
 	panic("cannot find index ~ header: " + element)
 }
 
+// findOptionalIndex is findIndex for a column the sheet may not have, it returns -1 when the header is missing
+func findOptionalIndex(arr []string, element string) int {
+	for index, e := range arr {
+		e = removeRedundantText(e)
+		if strings.EqualFold(e, element) {
+			return index
+		}
+	}
+	return -1
+}
+
+// optionalInt reads the cell of an optional column, a missing column or an empty cell is 0
+func optionalInt(record []string, index int) int {
+	if index < 0 || index >= len(record) {
+		return 0
+	}
+	return mustStringToInt(record[index], index)
+}
+
 func getPerkItemTypes(dataConfig *common.DataConfig, rawText string) []int {
 	var result []int
 	splitText := strings.Split(rawText, ",")
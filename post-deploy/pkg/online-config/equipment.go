@@ -2,6 +2,7 @@ package onlineconfig
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/ftk/post-deploy/pkg/common"
@@ -20,7 +21,7 @@ func getListEquipmentUpdate(sheetName string, dataConfig *common.DataConfig) ([]
 	var (
 		idIndex, tierIndex, weightIndex, nameIndex, descIndex, typeIndex, slotTypeIndex, advantageTypeIndex, twoHandedIndex,
 		atkIndex, defIndex, agiIndex, hpIndex, msIndex, goldCostIndex, recipeIndex, oldWeightIndex, bonusWeightIndex, shieldBarrierIndex,
-		perkRequireToCraftIndex, fameCostIndex, untradableIndex int
+		perkRequireToCraftIndex, fameCostIndex, untradableIndex, maxLevelIndex, counterIndex, dmgPercentIndex int
 	)
 	fmt.Println("RUN HERE")
 	for i := range rawData {
@@ -59,6 +60,10 @@ func getListEquipmentUpdate(sheetName string, dataConfig *common.DataConfig) ([]
 			perkRequireToCraftIndex = findIndex(record, "perk_require")
 			fameCostIndex = findIndex(record, "fame_spent")
 			untradableIndex = findIndex(record, "untradable")
+			// upgrade columns are optional, 0 when the sheet does not have them
+			maxLevelIndex = findOptionalIndex(record, "max_level")
+			counterIndex = findOptionalIndex(record, "counter")
+			dmgPercentIndex = findOptionalIndex(record, "dmg_percent")
 
 			l.Infow(
 				"list index",
@@ -82,6 +87,9 @@ func getListEquipmentUpdate(sheetName string, dataConfig *common.DataConfig) ([]
 				"perkRequireToCraftIndex", perkRequireToCraftIndex,
 				"fameCostIndex", fameCostIndex,
 				"untradableIndex", untradableIndex,
+				"maxLevelIndex", maxLevelIndex,
+				"counterIndex", counterIndex,
+				"dmgPercentIndex", dmgPercentIndex,
 			)
 			continue
 		}
@@ -97,6 +105,13 @@ func getListEquipmentUpdate(sheetName string, dataConfig *common.DataConfig) ([]
 		agi := mustStringToInt(record[agiIndex], agiIndex)
 		hp := mustStringToInt(record[hpIndex], hpIndex)
 		ms := mustStringToInt(record[msIndex], msIndex)
+		maxLevel := optionalInt(record, maxLevelIndex)
+		counter := optionalInt(record, counterIndex)
+		dmgPercent := optionalInt(record, dmgPercentIndex)
+		if maxLevel < 0 || maxLevel > math.MaxUint8 || counter < 0 || counter > math.MaxUint8 ||
+			dmgPercent < 0 || dmgPercent > math.MaxUint16 {
+			l.Panicw("invalid equipment upgrade fields", "data", record)
+		}
 		untradable := false
 		if strings.EqualFold(record[untradableIndex], "TRUE") {
 			untradable = true
@@ -147,6 +162,9 @@ func getListEquipmentUpdate(sheetName string, dataConfig *common.DataConfig) ([]
 				Ms:            ms,
 				BonusWeight:   bonusWeight,
 				ShieldBarrier: shieldBarrierIndex,
+				MaxLevel:      uint8(maxLevel),
+				Counter:       uint8(counter),
+				DmgPercent:    uint16(dmgPercent),
 			},
 		}
 		equipments = append(equipments, item)
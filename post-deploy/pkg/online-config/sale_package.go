@@ -0,0 +1,107 @@
+package onlineconfig
+
+import (
+	"strings"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"go.uber.org/zap"
+)
+
+func getSalePackageUpdate(sheetName string, dataConfig *common.DataConfig) ([]common.SalePackage, error) {
+	l := zap.S().With("func", "getSalePackageUpdate")
+	rawData, err := getSheetRawData(sheetName)
+	if err != nil {
+		l.Errorw("cannot csv reader", "err", err)
+		return nil, err
+	}
+	result := make([]common.SalePackage, 0)
+	var (
+		// id	crystalPrice	goldPrice	gold	achievements	items	requireTradable
+		idIndex, crystalPriceIndex, goldPriceIndex, goldIndex, achievementsIndex, itemsIndex, requireTradableIndex int
+		headerFound                                                                                                bool
+	)
+	for i := range rawData {
+		record := rawData[i]
+		if len(record) == 0 {
+			continue
+		}
+		if record[0] == "" { // empty row
+			l.Warnw("invalid sale package format", "data", record)
+			continue
+		}
+		if strings.EqualFold(record[0], "id") { // header
+			if headerFound {
+				l.Panicw("detect header more than one time", "value", record)
+			}
+			idIndex = 0
+			crystalPriceIndex = findIndex(record, "crystalPrice")
+			goldPriceIndex = findIndex(record, "goldPrice")
+			goldIndex = findIndex(record, "gold")
+			achievementsIndex = findIndex(record, "achievements")
+			itemsIndex = findIndex(record, "items")
+			requireTradableIndex = findIndex(record, "requireTradable")
+			l.Infow(
+				"list index",
+				"idIndex", idIndex,
+				"crystalPriceIndex", crystalPriceIndex,
+				"goldPriceIndex", goldPriceIndex,
+				"goldIndex", goldIndex,
+				"achievementsIndex", achievementsIndex,
+				"itemsIndex", itemsIndex,
+				"requireTradableIndex", requireTradableIndex,
+			)
+			headerFound = true
+			continue
+		}
+		if !headerFound {
+			l.Panicw("invalid sale package format, header must appear before data rows", "data", record)
+		}
+		if !isNumber(record[idIndex]) {
+			break // the data part is ended
+		}
+		salePackage := common.SalePackage{
+			Id:              mustStringToInt(record[idIndex], idIndex),
+			CrystalPrice:    uint32(mustStringToInt(removeRedundantText(record[crystalPriceIndex]), crystalPriceIndex)),
+			GoldPrice:       uint32(mustStringToInt(removeRedundantText(record[goldPriceIndex]), goldPriceIndex)),
+			Gold:            uint32(mustStringToInt(removeRedundantText(record[goldIndex]), goldIndex)),
+			RequireTradable: strings.EqualFold(removeRedundantText(record[requireTradableIndex]), "true"),
+		}
+		// achievements are ids, e.g. "2, 3"
+		if raw := removeRedundantText(record[achievementsIndex]); raw != "" {
+			for _, rawId := range strings.Split(raw, ",") {
+				achievementId := mustStringToInt(removeRedundantText(rawId), achievementsIndex)
+				salePackage.AchievementIds = append(salePackage.AchievementIds, achievementId)
+			}
+		}
+		// items are any items, e.g. "Gacha Ticket - 10\nHealing Potion - 5"
+		salePackage.ItemIds, salePackage.ItemAmounts = getSalePackageItems(record, record[itemsIndex], dataConfig)
+		result = append(result, salePackage)
+	}
+	return result, nil
+}
+
+// getSalePackageItems splits "name - amount" lines on the last "-" as item names may contain one
+func getSalePackageItems(record []string, rawS string, dataConfig *common.DataConfig) ([]int, []uint32) {
+	l := zap.S().With("func", "getSalePackageItems", "raw record", record)
+	var (
+		itemIds     []int
+		itemAmounts []uint32
+	)
+	if removeRedundantText(rawS) == "" {
+		return itemIds, itemAmounts
+	}
+	for _, data := range strings.Split(rawS, "\n") {
+		sep := strings.LastIndex(data, "-")
+		if sep < 0 {
+			l.Panicw("invalid sale package item, expect name - amount", "data", data)
+		}
+		itemName := removeRedundantText(data[:sep])
+		itemId, err := findItemIDByName(itemName, dataConfig)
+		if err != nil {
+			l.Panicw("invalid sale package item", "err", err)
+		}
+		itemIds = append(itemIds, itemId)
+		itemAmounts = append(itemAmounts, uint32(mustStringToInt(removeRedundantText(data[sep+1:]), 0)))
+	}
+	return itemIds, itemAmounts
+}
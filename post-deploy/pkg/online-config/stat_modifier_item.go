@@ -0,0 +1,99 @@
+package onlineconfig
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"go.uber.org/zap"
+)
+
+const (
+	statModifierItemType int = 26
+)
+
+func getListStatModifierItemUpdate(sheetName string, dataConfig *common.DataConfig) ([]common.Item, []common.ItemRecipe, error) {
+	l := zap.S().With("func", "getListStatModifierItemUpdate")
+	rawData, err := getSheetRawData(sheetName)
+	if err != nil {
+		l.Errorw("cannot csv reader", "err", err)
+		return nil, nil, err
+	}
+	statModifierItems := make([]common.Item, 0)
+	recipes := make([]common.ItemRecipe, 0)
+	var (
+		idIndex, tierIndex, weightIndex, nameIndex, descIndex, goldCostIndex, recipeIndex, untradableIndex,
+		durationIndex, atkPercentIndex, defPercentIndex, agiPercentIndex, msIndex int
+	)
+	for i := range rawData {
+		record := rawData[i]
+		if len(record) == 0 {
+			continue
+		}
+
+		if record[0] == "" { // empty row
+			l.Warnw("invalid stat modifier item data format", "data", record)
+			continue
+		}
+
+		if strings.EqualFold(record[0], "id") { // header
+			if tierIndex != 0 && nameIndex != 0 {
+				l.Panicw("detect header more than one time", "value", record)
+			}
+			idIndex = findIndex(record, "id")
+			nameIndex = findIndex(record, "name")
+			tierIndex = findIndex(record, "tier")
+			weightIndex = findIndex(record, "weight")
+			goldCostIndex = findIndex(record, "gold_cost")
+			recipeIndex = findIndex(record, "recipe")
+			descIndex = findIndex(record, "desc")
+			untradableIndex = findIndex(record, "untradable")
+			durationIndex = findIndex(record, "duration")
+			atkPercentIndex = findIndex(record, "atk_percent")
+			defPercentIndex = findIndex(record, "def_percent")
+			agiPercentIndex = findIndex(record, "agi_percent")
+			msIndex = findIndex(record, "ms")
+			continue
+		}
+
+		id := mustStringToInt(record[idIndex], idIndex)
+		tier := mustStringToInt(record[tierIndex], tierIndex)
+		weight := mustStringToInt(record[weightIndex], weightIndex)
+		duration := mustStringToInt(record[durationIndex], durationIndex)
+		if duration <= 0 || duration > math.MaxUint16 {
+			l.Panicw("invalid stat modifier duration", "data", record)
+		}
+		stats := make([]int16, 0, 4)
+		for _, index := range []int{atkPercentIndex, defPercentIndex, agiPercentIndex, msIndex} {
+			value := mustStringToInt(removeRedundantText(record[index]), index)
+			if value < math.MinInt16 || value > math.MaxInt16 {
+				l.Panicw("invalid stat modifier value", "data", record, "index", index)
+			}
+			stats = append(stats, int16(value))
+		}
+
+		statModifierItems = append(statModifierItems, common.Item{
+			Id:         id,
+			Type:       statModifierItemType,
+			Category:   2,
+			Tier:       tier,
+			Weight:     weight,
+			Untradable: strings.EqualFold(record[untradableIndex], "TRUE"),
+			Name:       removeRedundantText(record[nameIndex]),
+			Desc:       removeRedundantText(record[descIndex]),
+			StatModifierInfo: &common.StatModifierItemInfo{
+				Duration:   uint16(duration),
+				AtkPercent: stats[0],
+				DefPercent: stats[1],
+				AgiPercent: stats[2],
+				Ms:         stats[3],
+			},
+		})
+		recipes = append(recipes, common.ItemRecipe{
+			ItemId:      id,
+			Ingredients: getMaterialList(record, record[recipeIndex], dataConfig),
+			GoldCost:    mustStringToInt(record[goldCostIndex], goldCostIndex),
+		})
+	}
+	return statModifierItems, recipes, nil
+}
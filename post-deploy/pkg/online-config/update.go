@@ -47,5 +47,11 @@ func UpdateDataConfig(
 	// update pet component rate data config
 	updatePetComponentRateDataConfig(dataConfig, basePath, sheetUrlConfig, spreadSheetMetadata)
 
+	// update city level require data config
+	updateCityLevelRequireDataConfig(dataConfig, basePath, sheetUrlConfig, spreadSheetMetadata)
+
+	// update sale package data config
+	updateSalePackageDataConfig(dataConfig, basePath, sheetUrlConfig, spreadSheetMetadata)
+
 	l.Infow("update data config completed")
 }
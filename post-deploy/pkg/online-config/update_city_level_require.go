@@ -0,0 +1,54 @@
+package onlineconfig
+
+import (
+	"reflect"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"go.uber.org/zap"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func updateCityLevelRequireDataConfig(
+	dataConfig *common.DataConfig, basePath string,
+	sheetUrlConfig common.SheetUrlConfig, spreadSheetMetadata *sheets.Spreadsheet) {
+	l := zap.S().With("func", "updateCityLevelRequireDataConfig")
+	if sheetUrlConfig.ListCityLevelRequireUpdate == 0 {
+		l.Infow("city level require sheet is not configured, skip")
+		return
+	}
+	shouldRewriteFile := false
+	l.Infow("GET CITY LEVEL REQUIRE")
+	sheetName := findSheetNameById(sheetUrlConfig.ListCityLevelRequireUpdate, spreadSheetMetadata)
+	cityLevelRequires, err := getCityLevelRequireUpdate(sheetName, dataConfig)
+	if err != nil {
+		l.Errorw("cannot get city level require update", "err", err)
+		panic(err)
+	}
+	if dataConfig.CityLevelRequires == nil {
+		dataConfig.CityLevelRequires = make(map[string]common.CityLevelRequire)
+	}
+	for _, require := range cityLevelRequires {
+		currentRequire, ok := dataConfig.CityLevelRequires[intToString(int(require.Level))]
+		if reflect.DeepEqual(require, currentRequire) {
+			l.Infow("city level require data unchanged")
+			continue
+		}
+		if !ok {
+			l.Infow("detect new city level require", "data", require)
+		} else {
+			l.Infow("detect city level require update", "data", require)
+		}
+		shouldRewriteFile = true
+		dataConfig.CityLevelRequires[intToString(int(require.Level))] = require // add or update
+	}
+	if shouldRewriteFile {
+		if err := common.WriteSortedJsonFile(
+			basePath+"/data-config/cityLevelRequires.json",
+			"cityLevelRequires",
+			dataConfig.CityLevelRequires); err != nil {
+			l.Errorw("cannot update cityLevelRequires.json file", "err", err)
+		} else {
+			l.Infow("update cityLevelRequires.json successfully")
+		}
+	}
+}
@@ -118,6 +118,34 @@ func updateItemDataConfig(dataConfig *common.DataConfig, basePath string,
 		dataConfig.Items[intToString(healingItem.Id)] = healingItem // add or update
 	}
 
+	// update list stat modifier item
+	var listStatModifierItemRecipeUpdate []common.ItemRecipe
+	if sheetUrlConfig.ListStatModifierItemUpdate != 0 {
+		l.Infow("GET LIST STAT MODIFIER ITEM")
+		sheetName = findSheetNameById(sheetUrlConfig.ListStatModifierItemUpdate, spreadSheetMetadata)
+		var listStatModifierItemUpdate []common.Item
+		listStatModifierItemUpdate, listStatModifierItemRecipeUpdate, err = getListStatModifierItemUpdate(sheetName, dataConfig)
+		if err != nil {
+			l.Errorw("cannot get list stat modifier item update", "err", err)
+			panic(err)
+		}
+		for _, statModifierItem := range listStatModifierItemUpdate {
+			currentItem, ok := dataConfig.Items[intToString(statModifierItem.Id)]
+			statModifierItem.Weight = currentItem.Weight // keep old weight
+			if reflect.DeepEqual(statModifierItem, currentItem) {
+				l.Infow("stat modifier item data unchanged")
+				continue
+			}
+			if !ok {
+				l.Infow("detect new stat modifier item", "data", statModifierItem)
+			} else {
+				l.Infow("detect stat modifier item update", "data", statModifierItem)
+			}
+			shouldRewriteFile = true
+			dataConfig.Items[intToString(statModifierItem.Id)] = statModifierItem // add or update
+		}
+	}
+
 	// update list scroll item
 	l.Infow("GET LIST SCROLL ITEM")
 	sheetName = findSheetNameById(sheetUrlConfig.ListScrollUpdate, spreadSheetMetadata)
@@ -276,6 +304,20 @@ func updateItemDataConfig(dataConfig *common.DataConfig, basePath string,
 		shouldRewriteFile = true
 		dataConfig.ItemRecipes[intToString(recipe.ItemId)] = recipe // add
 	}
+	for _, recipe := range listStatModifierItemRecipeUpdate {
+		currentRecipe, ok := dataConfig.ItemRecipes[intToString(recipe.ItemId)]
+		if reflect.DeepEqual(recipe, currentRecipe) {
+			// l.Infow("recipe data unchanged")
+			continue
+		}
+		if !ok {
+			l.Infow("detect new recipe", "data", recipe)
+		} else {
+			l.Infow("detect recipe update", "data", recipe)
+		}
+		shouldRewriteFile = true
+		dataConfig.ItemRecipes[intToString(recipe.ItemId)] = recipe // add
+	}
 	for _, recipe := range listScrollItemRecipeUpdate {
 		currentRecipe, ok := dataConfig.ItemRecipes[intToString(recipe.ItemId)]
 		if reflect.DeepEqual(recipe, currentRecipe) {
@@ -0,0 +1,54 @@
+package onlineconfig
+
+import (
+	"reflect"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"go.uber.org/zap"
+	sheets "google.golang.org/api/sheets/v4"
+)
+
+func updateSalePackageDataConfig(
+	dataConfig *common.DataConfig, basePath string,
+	sheetUrlConfig common.SheetUrlConfig, spreadSheetMetadata *sheets.Spreadsheet) {
+	l := zap.S().With("func", "updateSalePackageDataConfig")
+	if sheetUrlConfig.ListSalePackageUpdate == 0 {
+		l.Infow("sale package sheet is not configured, skip")
+		return
+	}
+	shouldRewriteFile := false
+	l.Infow("GET SALE PACKAGE")
+	sheetName := findSheetNameById(sheetUrlConfig.ListSalePackageUpdate, spreadSheetMetadata)
+	salePackages, err := getSalePackageUpdate(sheetName, dataConfig)
+	if err != nil {
+		l.Errorw("cannot get sale package update", "err", err)
+		panic(err)
+	}
+	if dataConfig.SalePackages == nil {
+		dataConfig.SalePackages = make(map[string]common.SalePackage)
+	}
+	for _, salePackage := range salePackages {
+		currentSalePackage, ok := dataConfig.SalePackages[intToString(salePackage.Id)]
+		if reflect.DeepEqual(salePackage, currentSalePackage) {
+			l.Infow("sale package data unchanged")
+			continue
+		}
+		if !ok {
+			l.Infow("detect new sale package", "data", salePackage)
+		} else {
+			l.Infow("detect sale package update", "data", salePackage)
+		}
+		shouldRewriteFile = true
+		dataConfig.SalePackages[intToString(salePackage.Id)] = salePackage // add or update
+	}
+	if shouldRewriteFile {
+		if err := common.WriteSortedJsonFile(
+			basePath+"/data-config/salePackages.json",
+			"salePackages",
+			dataConfig.SalePackages); err != nil {
+			l.Errorw("cannot update salePackages.json file", "err", err)
+		} else {
+			l.Infow("update salePackages.json successfully")
+		}
+	}
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func AchievementCallData(achievement common.Achievement) ([]byte, error) {
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticFields("Achievement",
 		uint16(achievement.Stats.Atk),
 		uint16(achievement.Stats.Def),
 		uint16(achievement.Stats.Agi),
@@ -19,10 +19,16 @@ func AchievementCallData(achievement common.Achievement) ([]byte, error) {
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(int64(achievement.Id)))),
 	}
-	encodedLength := mud.EncodeLengths([]int{
+	encodedLength, err := mud.EncodeLengths([]int{
 		len(stringToBytes(achievement.Name)),
 	})
-	dynamicData := simpleEncodePacked(stringToBytes(achievement.Name))
+	if err != nil {
+		return nil, err
+	}
+	dynamicData, err := encodeDynamicFields("Achievement", stringToBytes(achievement.Name))
+	if err != nil {
+		return nil, err
+	}
 	mt := mud.NewMudTable("Achievement", "app", "")
 	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
 }
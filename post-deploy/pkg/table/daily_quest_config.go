@@ -7,7 +7,7 @@ import (
 
 func DailyQuestConfigCallData(dqc common.DailyQuestConfig) ([]byte, error) {
 	keyTuple := make([][32]byte, 0)
-	staticData, err := encodePacked(dqc.MoveNum, dqc.FarmNum, dqc.PvpNum, dqc.PveNum, dqc.RewardExp, dqc.RewardGold)
+	staticData, err := encodeStaticFields("DailyQuestConfig", dqc.MoveNum, dqc.FarmNum, dqc.PvpNum, dqc.PveNum, dqc.RewardExp, dqc.RewardGold)
 	if err != nil {
 		return nil, err
 	}
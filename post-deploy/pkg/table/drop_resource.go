@@ -2,6 +2,7 @@ package table
 
 import (
 	"math/big"
+	"sort"
 
 	"github.com/ftk/post-deploy/pkg/common"
 	"github.com/ftk/post-deploy/pkg/mud"
@@ -24,7 +25,12 @@ func DropResourceCallData(config common.DataConfig, minTier int) ([]byte, error)
 			resourceIds = append(resourceIds, big.NewInt(int64(item.Id)))
 		}
 	}
-	dynamicData := encodeUint256Array(resourceIds)
+	// items is a map, sort so that the record is the same for every build
+	sort.Slice(resourceIds, func(i, j int) bool { return resourceIds[i].Cmp(resourceIds[j]) < 0 })
+	dynamicData, err := encodeDynamicField("DropResource", 0, resourceIds)
+	if err != nil {
+		return nil, err
+	}
 	keyTuple := [][32]byte{}
 	mt := mud.NewMudTable("DropResource", "app", "")
 	return mt.SetDynamicFieldRawCalldata(keyTuple, 0, dynamicData)
@@ -0,0 +1,67 @@
+package table
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/ftk/post-deploy/pkg/mud"
+)
+
+// gachaTotalPercent is TOTAL_PERCENT of GachaSystem, 10000 ~ 100.00%
+const gachaTotalPercent = 10_000
+
+func GachaV5CallData(gacha common.Gacha) ([]byte, error) {
+	if len(gacha.Items) == 0 {
+		return nil, fmt.Errorf("invalid gacha %d: no items", gacha.Id)
+	}
+	if gacha.TicketValue == 0 && gacha.TicketItemId == 0 {
+		return nil, fmt.Errorf("invalid gacha %d: either ticketValue or ticketItemId is required", gacha.Id)
+	}
+	var (
+		itemIds      = make([]*big.Int, 0, len(gacha.Items))
+		amounts      = make([]uint32, 0, len(gacha.Items))
+		percents     = make([]uint16, 0, len(gacha.Items))
+		totalPercent = 0
+	)
+	for _, item := range gacha.Items {
+		if item.Amount == 0 {
+			return nil, fmt.Errorf("invalid gacha %d: item %d amount must be positive", gacha.Id, item.ItemId)
+		}
+		if item.Percent == 0 {
+			return nil, fmt.Errorf("invalid gacha %d: item %d percent must be at least 1", gacha.Id, item.ItemId)
+		}
+		itemIds = append(itemIds, big.NewInt(int64(item.ItemId)))
+		amounts = append(amounts, item.Amount)
+		percents = append(percents, item.Percent)
+		totalPercent += int(item.Percent)
+	}
+	if totalPercent != gachaTotalPercent {
+		return nil, fmt.Errorf("invalid gacha %d: total percent must be 10000, got %d", gacha.Id, totalPercent)
+	}
+	staticData, err := encodeStaticFields("GachaV5",
+		big.NewInt(gacha.StartTime),
+		big.NewInt(int64(gacha.TicketValue)),
+		big.NewInt(int64(gacha.TicketItemId)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	encodedLength, err := mud.EncodeLengths([]int{
+		32 * len(itemIds),
+		4 * len(amounts),
+		2 * len(percents),
+	})
+	if err != nil {
+		return nil, err
+	}
+	dynamicData, err := encodeDynamicFields("GachaV5", itemIds, amounts, percents)
+	if err != nil {
+		return nil, err
+	}
+	keyTuple := [][32]byte{
+		[32]byte(encodeUint256(big.NewInt(int64(gacha.Id)))),
+	}
+	mt := mud.NewMudTable("GachaV5", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
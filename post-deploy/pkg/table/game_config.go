@@ -0,0 +1,61 @@
+package table
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/ftk/post-deploy/pkg/mud"
+)
+
+func InventoryConfigCallData(config common.InventoryConfig) ([]byte, error) {
+	if config.BaseWeight == 0 || config.BaseWeight > config.MaxWeight {
+		return nil, fmt.Errorf("invalid inventory config: base weight %d must be in (0, max weight %d]",
+			config.BaseWeight, config.MaxWeight)
+	}
+	keyTuple := make([][32]byte, 0)
+	staticData, err := encodeStaticFields("InventoryConfig", config.BaseWeight, config.MaxWeight)
+	if err != nil {
+		return nil, err
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("InventoryConfig", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
+
+func MovementConfigCallData(config common.MovementConfig) ([]byte, error) {
+	if config.BaseMovementSpeed == 0 || config.BaseMovementSpeed > config.MaxMovementSpeed {
+		return nil, fmt.Errorf("invalid movement config: base speed %d must be in [1, max speed %d]",
+			config.BaseMovementSpeed, config.MaxMovementSpeed)
+	}
+	if config.Duration == 0 {
+		return nil, fmt.Errorf("invalid movement config: duration must be positive")
+	}
+	keyTuple := make([][32]byte, 0)
+	staticData, err := encodeStaticFields("MovementConfig",
+		config.BaseMovementSpeed, config.MaxMovementSpeed, config.Duration)
+	if err != nil {
+		return nil, err
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("MovementConfig", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
+
+func ExpAmpConfigCallData(config common.ExpAmpConfig) ([]byte, error) {
+	if config.ExpireTime < 0 {
+		return nil, fmt.Errorf("invalid exp amp config: negative expire time %d", config.ExpireTime)
+	}
+	keyTuple := make([][32]byte, 0)
+	staticData, err := encodeStaticFields("ExpAmpConfig",
+		config.FarmingPerkAmp, config.PveExpAmp, config.PvePerkAmp, big.NewInt(config.ExpireTime))
+	if err != nil {
+		return nil, err
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("ExpAmpConfig", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
@@ -1,20 +1,17 @@
 package table
 
 import (
+	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/ftk/post-deploy/pkg/common"
 	"github.com/ftk/post-deploy/pkg/mud"
 )
 
-const (
-	itemWeightCacheFieldLayout = "0x0004010004000000000000000000000000000000000000000000000000000000"
-	skinInfoFieldLayout        = "0x0001010001000000000000000000000000000000000000000000000000000000"
-)
-
 func ItemCallData(item common.Item) ([]byte, error) {
 	// zap.S().Infow("item category", "id", item.Id, "value", common.MapItemCategoryTypes[item.Category])
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticFields("ItemV2",
 		uint8(item.Category),
 		uint8(item.Type),
 		uint32(item.Weight),
@@ -27,14 +24,20 @@ func ItemCallData(item common.Item) ([]byte, error) {
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(int64(item.Id)))),
 	}
-	encodedLength := mud.EncodeLengths([]int{len(stringToBytes(item.Name))})
-	dynamicData := simpleEncodePacked(stringToBytes(item.Name))
+	encodedLength, err := mud.EncodeLengths([]int{len(stringToBytes(item.Name))})
+	if err != nil {
+		return nil, err
+	}
+	dynamicData, err := encodeDynamicFields("ItemV2", stringToBytes(item.Name))
+	if err != nil {
+		return nil, err
+	}
 	mt := mud.NewMudTable("ItemV2", "app", "")
 	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
 }
 
 func EquipmentItemInfoCallData(equipmentInfo common.EquipmentInfo, itemId int) ([]byte, error) {
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticFields("EquipmentInfo",
 		uint8(equipmentInfo.SlotType),
 		uint8(equipmentInfo.AdvantageType),
 		equipmentInfo.TwoHanded,
@@ -56,11 +59,26 @@ func EquipmentItemInfoCallData(equipmentInfo common.EquipmentInfo, itemId int) (
 	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
 }
 
+// grindSlotTypes are the slot types EquipmentSystem.updateGrindSlot accepts for CharGrindSlot
+var grindSlotTypes = map[int]bool{
+	0: true, // Weapon
+	1: true, // SubWeapon
+	2: true, // Armor
+	3: true, // Headgear
+	4: true, // Footwear
+	5: true, // Mount
+}
+
 func EquipmentItemInfo2V2CallData(equipmentInfo common.EquipmentInfo, itemId int) ([]byte, error) {
-	staticData, err := encodePacked(
-		uint8(0),  // max level
-		uint8(0),  // counter
-		uint16(0), // dmg percentage
+	hasUpgrade := equipmentInfo.MaxLevel != 0 || equipmentInfo.Counter != 0 || equipmentInfo.DmgPercent != 0
+	if hasUpgrade && !grindSlotTypes[equipmentInfo.SlotType] {
+		return nil, fmt.Errorf("equipment %d: slot type %d does not support grinding, upgrade fields must be 0",
+			itemId, equipmentInfo.SlotType)
+	}
+	staticData, err := encodeStaticFields("EquipmentInfo2V2",
+		equipmentInfo.MaxLevel,
+		equipmentInfo.Counter,
+		equipmentInfo.DmgPercent,
 		uint32(equipmentInfo.BonusWeight),
 		uint32(equipmentInfo.ShieldBarrier),
 	)
@@ -78,7 +96,7 @@ func EquipmentItemInfo2V2CallData(equipmentInfo common.EquipmentInfo, itemId int
 }
 
 func HealingItemInfoCallData(healingInfo common.HealingInfo, itemId int) ([]byte, error) {
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticFields("HealingItemInfo",
 		uint32(healingInfo.HpRestore),
 	)
 	if err != nil {
@@ -93,8 +111,31 @@ func HealingItemInfoCallData(healingInfo common.HealingInfo, itemId int) ([]byte
 	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
 }
 
+func StatModifierItemInfoCallData(statModifierInfo common.StatModifierItemInfo, itemId int) ([]byte, error) {
+	if statModifierInfo.Duration == 0 {
+		return nil, fmt.Errorf("stat modifier item %d: duration must be positive", itemId)
+	}
+	staticData, err := encodeStaticFields("StatModifierItemInfo",
+		statModifierInfo.Duration,
+		statModifierInfo.AtkPercent,
+		statModifierInfo.DefPercent,
+		statModifierInfo.AgiPercent,
+		statModifierInfo.Ms,
+	)
+	if err != nil {
+		return nil, err
+	}
+	keyTuple := [][32]byte{
+		[32]byte(encodeUint256(big.NewInt(int64(itemId)))),
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("StatModifierItemInfo", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
+
 func ResourceItemInfoCallData(resourceInfo common.ResourceInfo, itemId int) ([]byte, error) {
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticFields("ResourceInfo",
 		uint8(resourceInfo.ResourceType),
 	)
 	if err != nil {
@@ -109,8 +150,41 @@ func ResourceItemInfoCallData(resourceInfo common.ResourceInfo, itemId int) ([]b
 	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
 }
 
+func CardInfoCallData(cardInfo common.CardInfo, itemId int) ([]byte, error) {
+	for _, side := range []struct {
+		name  string
+		value int
+	}{
+		{"top", cardInfo.Top},
+		{"left", cardInfo.Left},
+		{"right", cardInfo.Right},
+		{"bottom", cardInfo.Bottom},
+	} {
+		// the contracts do not bound card values, only the uint16 columns do
+		if side.value < 0 || side.value > math.MaxUint16 {
+			return nil, fmt.Errorf("card %d: %s value %d does not fit uint16", itemId, side.name, side.value)
+		}
+	}
+	staticData, err := encodeStaticFields("CardInfo",
+		uint16(cardInfo.Top),
+		uint16(cardInfo.Left),
+		uint16(cardInfo.Right),
+		uint16(cardInfo.Bottom),
+	)
+	if err != nil {
+		return nil, err
+	}
+	keyTuple := [][32]byte{
+		[32]byte(encodeUint256(big.NewInt(int64(itemId)))),
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("CardInfo", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
+
 func BuffItemInfoCallData(buffInfo common.BuffItemInfo, itemId int) ([]byte, error) {
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticFields("BuffItemInfoV3",
 		uint16(buffInfo.Range), uint32(buffInfo.Duration), uint8(buffInfo.NumTarget),
 		buffInfo.SelfCastOnly, uint8(buffInfo.Type), buffInfo.IsBuff,
 	)
@@ -127,7 +201,7 @@ func BuffItemInfoCallData(buffInfo common.BuffItemInfo, itemId int) ([]byte, err
 }
 
 func BuffDmgInfoCallData(skillInfo common.InstantDamage, itemId int) ([]byte, error) {
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticFields("BuffDmg",
 		uint32(skillInfo.Dmg), skillInfo.IsAbsDmg,
 	)
 	if err != nil {
@@ -143,7 +217,7 @@ func BuffDmgInfoCallData(skillInfo common.InstantDamage, itemId int) ([]byte, er
 }
 
 func BuffStatCallData(statBuff common.StatsModify, itemId int) ([]byte, error) {
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticFields("BuffStatV4",
 		statBuff.AtkPercent, statBuff.DefPercent, statBuff.AgiPercent,
 		int8(statBuff.Sp), int8(statBuff.Ms), statBuff.SlowPercent, uint32(statBuff.Dmg), statBuff.IsAbsDmg,
 	)
@@ -160,8 +234,8 @@ func BuffStatCallData(statBuff common.StatsModify, itemId int) ([]byte, error) {
 }
 
 func BuffExpCallData(statBuff common.ExpAmplify, itemId int) ([]byte, error) {
-	staticData, err := encodePacked(
-		uint16(statBuff.FarmingPerkAmp), uint16(statBuff.PveExpAmp), uint16(statBuff.PveExpAmp),
+	staticData, err := encodeStaticFields("BuffExp",
+		uint16(statBuff.FarmingPerkAmp), uint16(statBuff.PveExpAmp), uint16(statBuff.PvePerkAmp),
 	)
 	if err != nil {
 		return nil, err
@@ -176,7 +250,7 @@ func BuffExpCallData(statBuff common.ExpAmplify, itemId int) ([]byte, error) {
 }
 
 func ItemWeightCacheCallData(item common.Item) ([]byte, error) {
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticField("ItemWeightCache", 0,
 		uint32(item.OldWeight),
 	)
 	if err != nil {
@@ -185,12 +259,12 @@ func ItemWeightCacheCallData(item common.Item) ([]byte, error) {
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(int64(item.Id)))),
 	}
-	mt := mud.NewMudTable("ItemWeightCache", "app", itemWeightCacheFieldLayout)
+	mt := mud.NewMudTable("ItemWeightCache", "app", "")
 	return mt.SetStaticFieldRawCalldata(keyTuple, 0, staticData)
 }
 
 func SkinInfoCallData(item common.Item) ([]byte, error) {
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticField("SkinInfo", 0,
 		uint8(item.SkinInfo.SlotType),
 	)
 	if err != nil {
@@ -199,6 +273,6 @@ func SkinInfoCallData(item common.Item) ([]byte, error) {
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(int64(item.Id)))),
 	}
-	mt := mud.NewMudTable("SkinInfo", "app", skinInfoFieldLayout)
+	mt := mud.NewMudTable("SkinInfo", "app", "")
 	return mt.SetStaticFieldRawCalldata(keyTuple, 0, staticData)
 }
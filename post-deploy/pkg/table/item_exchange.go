@@ -17,9 +17,12 @@ func CollectionExcCallData(itemEx common.ItemExchange) ([]byte, error) {
 		inputItemIds = append(inputItemIds, big.NewInt(int64(ingredient.ItemId)))
 		inputItemAmounts = append(inputItemAmounts, uint32(ingredient.Amount))
 	}
-	encodedLength := mud.EncodeLengths([]int{
+	encodedLength, err := mud.EncodeLengths([]int{
 		len(itemEx.Ingredients) * 32, len(itemEx.Ingredients) * 4})
-	dynamicData, err := encodePacked(inputItemIds, inputItemAmounts)
+	if err != nil {
+		return nil, err
+	}
+	dynamicData, err := encodeDynamicFields("CollectionExcV2", inputItemIds, inputItemAmounts)
 	if err != nil {
 		return nil, err
 	}
@@ -9,16 +9,19 @@ import (
 )
 
 func ItemRecipeCallData(recipe common.ItemRecipe) ([]byte, error) {
-	staticData, err := encodePacked(uint32(recipe.GoldCost), uint32(recipe.FameCost))
+	staticData, err := encodeStaticFields("ItemRecipeV3", uint32(recipe.GoldCost), uint32(recipe.FameCost))
 	if err != nil {
 		return nil, err
 	}
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(int64(recipe.ItemId)))),
 	}
-	encodedLength := mud.EncodeLengths([]int{
+	encodedLength, err := mud.EncodeLengths([]int{
 		len(recipe.PerkItemTypes), len(recipe.RequiredPerkLevels),
 		len(recipe.Ingredients) * 32, len(recipe.Ingredients) * 4})
+	if err != nil {
+		return nil, err
+	}
 	var (
 		itemIds            []*big.Int
 		amounts            []uint32
@@ -37,7 +40,7 @@ func ItemRecipeCallData(recipe common.ItemRecipe) ([]byte, error) {
 		requiredPerkLevels = append(requiredPerkLevels, uint8(recipe.RequiredPerkLevels[index]))
 	}
 
-	dynamicData, err := encodePacked(perkTypes, requiredPerkLevels, itemIds, amounts)
+	dynamicData, err := encodeDynamicFields("ItemRecipeV3", perkTypes, requiredPerkLevels, itemIds, amounts)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,110 @@
+package table
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/ftk/post-deploy/pkg/mud"
+)
+
+// limits checked by KingSystem when a king changes the settings
+const (
+	maxMarketFee   = 100
+	maxCrystalFee  = 10 // Config.MAX_CRYSTAL_FEE
+	maxFamePenalty = 100
+)
+
+func MarketFeeCallData(marketKingdomId, charKingdomId uint8, fee uint8) ([]byte, error) {
+	if fee > maxMarketFee {
+		return nil, fmt.Errorf("market fee %d of kingdom %d => %d exceeds %d", fee, marketKingdomId, charKingdomId, maxMarketFee)
+	}
+	staticData, err := encodeStaticFields("MarketFee", fee)
+	if err != nil {
+		return nil, err
+	}
+	keyTuple := [][32]byte{
+		[32]byte(encodeUint256(big.NewInt(int64(marketKingdomId)))),
+		[32]byte(encodeUint256(big.NewInt(int64(charKingdomId)))),
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("MarketFee", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
+
+func CrystalFeeCallData(kingdomId uint8, fee uint8) ([]byte, error) {
+	if fee > maxCrystalFee {
+		return nil, fmt.Errorf("crystal fee %d of kingdom %d exceeds %d", fee, kingdomId, maxCrystalFee)
+	}
+	staticData, err := encodeStaticFields("CrystalFee", fee)
+	if err != nil {
+		return nil, err
+	}
+	keyTuple := [][32]byte{
+		[32]byte(encodeUint256(big.NewInt(int64(kingdomId)))),
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("CrystalFee", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
+
+func KingSettingCallData(kingdomId uint8, economy common.KingdomEconomy) ([]byte, error) {
+	if economy.PvpFamePenalty > maxFamePenalty || economy.CaptureTilePenalty > maxFamePenalty {
+		return nil, fmt.Errorf("fame penalty of kingdom %d exceeds %d", kingdomId, maxFamePenalty)
+	}
+	staticData, err := encodeStaticFields("KingSetting", economy.PvpFamePenalty, economy.CaptureTilePenalty)
+	if err != nil {
+		return nil, err
+	}
+	keyTuple := [][32]byte{
+		[32]byte(encodeUint256(big.NewInt(int64(kingdomId)))),
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("KingSetting", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
+
+func KingSetting2CallData(kingdomId uint8, economy common.KingdomEconomy) ([]byte, error) {
+	staticData, err := encodeStaticFields("KingSetting2", economy.WithdrawWeightLimit)
+	if err != nil {
+		return nil, err
+	}
+	keyTuple := [][32]byte{
+		[32]byte(encodeUint256(big.NewInt(int64(kingdomId)))),
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("KingSetting2", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
+
+// AllianceV2CallData sets an approved alliance under both key orders, KingSystem checks either order
+// and deletes both when the alliance is broken
+func AllianceV2CallData(kingdomA, kingdomB uint8) ([][]byte, error) {
+	if kingdomA == kingdomB {
+		return nil, fmt.Errorf("kingdom %d cannot be allied with itself", kingdomA)
+	}
+	staticData, err := encodeStaticFields("AllianceV2", true, true)
+	if err != nil {
+		return nil, err
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("AllianceV2", "app", "")
+	callData := make([][]byte, 0, 2)
+	for _, pair := range [][2]uint8{{kingdomA, kingdomB}, {kingdomB, kingdomA}} {
+		keyTuple := [][32]byte{
+			[32]byte(encodeUint256(big.NewInt(int64(pair[0])))),
+			[32]byte(encodeUint256(big.NewInt(int64(pair[1])))),
+		}
+		allianceCallData, err := mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+		if err != nil {
+			return nil, err
+		}
+		callData = append(callData, allianceCallData)
+	}
+	return callData, nil
+}
@@ -1,19 +1,17 @@
 package table
 
 import (
+	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/ftk/post-deploy/pkg/common"
 	"github.com/ftk/post-deploy/pkg/mud"
 )
 
-func MapConfigCallData() ([]byte, error) {
+func MapConfigCallData(width, height uint32) ([]byte, error) {
 	keyTuple := make([][32]byte, 0)
-	var (
-		width  uint32 = 4294967295
-		height uint32 = 4294967295
-	)
-	staticData, err := encodePacked(width, height)
+	staticData, err := encodeStaticFields("MapConfig", width, height)
 	if err != nil {
 		return nil, err
 	}
@@ -27,12 +25,18 @@ func KingdomCallData(kd common.Kingdom) ([]byte, error) {
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(int64(kd.Id)))),
 	}
-	staticData, err := encodePacked(big.NewInt(int64(kd.CapitalId)))
+	staticData, err := encodeStaticFields("Kingdom", big.NewInt(int64(kd.CapitalId)))
+	if err != nil {
+		return nil, err
+	}
+	encodedLength, err := mud.EncodeLengths([]int{len(stringToBytes(kd.Name))})
+	if err != nil {
+		return nil, err
+	}
+	dynamicData, err := encodeDynamicFields("Kingdom", stringToBytes(kd.Name))
 	if err != nil {
 		return nil, err
 	}
-	encodedLength := mud.EncodeLengths([]int{len(stringToBytes(kd.Name))})
-	dynamicData := simpleEncodePacked(stringToBytes(kd.Name))
 	mt := mud.NewMudTable("Kingdom", "app", "")
 	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
 }
@@ -41,14 +45,105 @@ func CityCallData(city common.City) ([]byte, error) {
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(int64(city.Id)))),
 	}
-	staticData, err := encodePacked(city.X, city.Y, city.IsCapital, city.KingdomId, city.Level)
+	staticData, err := encodeStaticFields("City", city.X, city.Y, city.IsCapital, city.KingdomId, city.Level)
 	if err != nil {
 		return nil, err
 	}
-	encodedLength := mud.EncodeLengths([]int{
+	encodedLength, err := mud.EncodeLengths([]int{
 		len(stringToBytes(city.Name)),
 	})
-	dynamicData := simpleEncodePacked(stringToBytes(city.Name))
+	if err != nil {
+		return nil, err
+	}
+	dynamicData, err := encodeDynamicFields("City", stringToBytes(city.Name))
+	if err != nil {
+		return nil, err
+	}
 	mt := mud.NewMudTable("City", "app", "")
 	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
 }
+
+// cityMaxLevel is the max level of CitySystem.upgradeCity
+const cityMaxLevel = 3
+
+func CResourceRequireCallData(require common.CityLevelRequire) ([]byte, error) {
+	if require.Level == 0 || require.Level > cityMaxLevel {
+		return nil, fmt.Errorf("city level %d out of range [1, %d]", require.Level, cityMaxLevel)
+	}
+	var (
+		resourceIds = make([]*big.Int, 0, len(require.Resources))
+		amounts     = make([]uint32, 0, len(require.Resources))
+	)
+	for _, resource := range require.Resources {
+		if resource.Amount <= 0 || resource.Amount > math.MaxUint32 {
+			return nil, fmt.Errorf("city level %d: resource %d amount %d out of range", require.Level, resource.ItemId, resource.Amount)
+		}
+		resourceIds = append(resourceIds, big.NewInt(int64(resource.ItemId)))
+		amounts = append(amounts, uint32(resource.Amount))
+	}
+	keyTuple := [][32]byte{
+		[32]byte(encodeUint256(big.NewInt(int64(require.Level)))),
+	}
+	staticData, err := encodeStaticFields("CResourceRequire")
+	if err != nil {
+		return nil, err
+	}
+	encodedLength, err := mud.EncodeLengths([]int{
+		32 * len(resourceIds),
+		4 * len(amounts),
+	})
+	if err != nil {
+		return nil, err
+	}
+	dynamicData, err := encodeDynamicFields("CResourceRequire", resourceIds, amounts)
+	if err != nil {
+		return nil, err
+	}
+	mt := mud.NewMudTable("CResourceRequire", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
+
+func RestrictLocV2CallData(location common.Location, cityId int) ([]byte, error) {
+	keyTuple := [][32]byte{
+		[32]byte(encodeUint256(big.NewInt(int64(location.X)))),
+		[32]byte(encodeUint256(big.NewInt(int64(location.Y)))),
+	}
+	staticData, err := encodeStaticFields("RestrictLocV2", big.NewInt(int64(cityId)), true)
+	if err != nil {
+		return nil, err
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("RestrictLocV2", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
+
+func NonOccupyTileCallData(location common.Location) ([]byte, error) {
+	keyTuple := [][32]byte{
+		[32]byte(encodeUint256(big.NewInt(int64(location.X)))),
+		[32]byte(encodeUint256(big.NewInt(int64(location.Y)))),
+	}
+	staticData, err := encodeStaticFields("NonOccupyTile", true)
+	if err != nil {
+		return nil, err
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("NonOccupyTile", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
+
+func UnmovableCallData(location common.Location) ([]byte, error) {
+	keyTuple := [][32]byte{
+		[32]byte(encodeUint256(big.NewInt(int64(location.X)))),
+		[32]byte(encodeUint256(big.NewInt(int64(location.Y)))),
+	}
+	staticData, err := encodeStaticFields("Unmovable", true)
+	if err != nil {
+		return nil, err
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("Unmovable", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
@@ -14,16 +14,19 @@ func MonsterCallData(monster common.Monster) ([]byte, error) {
 		return nil, fmt.Errorf("invalid monster data len itemIds = %d len itemAmounts = %d",
 			len(monster.ItemIds), len(monster.ItemAmounts))
 	}
-	staticData, err := encodePacked(uint8(monster.Grow), uint32(monster.Exp), uint32(monster.PerkExp), monster.IsBoss)
+	staticData, err := encodeStaticFields("Monster", uint8(monster.Grow), uint32(monster.Exp), uint32(monster.PerkExp), monster.IsBoss)
 	if err != nil {
 		return nil, err
 	}
-	encodedLength := mud.EncodeLengths([]int{
+	encodedLength, err := mud.EncodeLengths([]int{
 		len(stringToBytes(monster.Name)),
 		32 * len(monster.SkillIds),
 		32 * len(monster.ItemIds),
 		4 * len(monster.ItemAmounts),
 	})
+	if err != nil {
+		return nil, err
+	}
 	skillIds := make([]*big.Int, len(monster.SkillIds))
 	for index, skillId := range monster.SkillIds {
 		skillIds[index] = big.NewInt(int64(skillId))
@@ -37,7 +40,7 @@ func MonsterCallData(monster common.Monster) ([]byte, error) {
 		itemAmounts[index] = uint32(itemAmount)
 	}
 	// encodedResourceAmounts, _ := encodePacked(itemAmounts)
-	dynamicData, err := encodePacked(
+	dynamicData, err := encodeDynamicFields("Monster",
 		stringToBytes(monster.Name),
 		skillIds,
 		itemIds,
@@ -54,7 +57,7 @@ func MonsterCallData(monster common.Monster) ([]byte, error) {
 }
 
 func MonsterStatsCallData(monsterId int, stats common.MonsterStats) ([]byte, error) {
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticFields("MonsterStats",
 		uint32(stats.Hp), uint16(stats.Atk), uint16(stats.Def), uint16(stats.Agi), uint8(stats.Sp))
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(int64(monsterId)))),
@@ -73,7 +76,7 @@ func BossInfosCallData(monsterId int, bossInfo common.BossInfo, x int32, y int32
 	if respawnTime == 0 {
 		zap.S().Panicw("invalid respawn duration data", "bossInfo", bossInfo)
 	}
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticFields("BossInfo",
 		uint32(bossInfo.Barrier),
 		uint32(bossInfo.Hp),
 		uint32(bossInfo.Crystal),
@@ -97,7 +100,7 @@ func BossInfosCallData(monsterId int, bossInfo common.BossInfo, x int32, y int32
 }
 
 func MonsterLocationCallData(location common.Location, monsterId, level, advantageType int) ([]byte, error) {
-	staticData, err := encodePacked(uint16(level), uint8(advantageType))
+	staticData, err := encodeStaticFields("MonsterLocation", uint16(level), uint8(advantageType))
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(int64(location.X)))),
 		[32]byte(encodeUint256(big.NewInt(int64(location.Y)))),
@@ -8,14 +8,20 @@ import (
 )
 
 func NpcCallData(npc common.Npc) ([]byte, error) {
-	staticData, err := encodePacked(big.NewInt(npc.CityId), npc.X, npc.Y)
+	staticData, err := encodeStaticFields("Npc", big.NewInt(npc.CityId), npc.X, npc.Y)
 	if err != nil {
 		return nil, err
 	}
-	encodedLength := mud.EncodeLengths([]int{
+	encodedLength, err := mud.EncodeLengths([]int{
 		len(stringToBytes(npc.Name)),
 	})
-	dynamicData := simpleEncodePacked(stringToBytes(npc.Name))
+	if err != nil {
+		return nil, err
+	}
+	dynamicData, err := encodeDynamicFields("Npc", stringToBytes(npc.Name))
+	if err != nil {
+		return nil, err
+	}
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(npc.Id))),
 	}
@@ -27,7 +33,7 @@ func NpcShopCallData(city common.City) ([]byte, error) {
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(int64(city.Id)))),
 	}
-	staticData, err := encodePacked(uint32(100_000))
+	staticData, err := encodeStaticFields("NpcShop", uint32(100_000))
 	if err != nil {
 		return nil, err
 	}
@@ -36,3 +42,19 @@ func NpcShopCallData(city common.City) ([]byte, error) {
 	mt := mud.NewMudTable("NpcShop", "app", "")
 	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
 }
+
+func NpcShopInventoryCallData(cityId int, stock common.NpcShopStock) ([]byte, error) {
+	keyTuple := [][32]byte{
+		[32]byte(encodeUint256(big.NewInt(int64(cityId)))),
+		[32]byte(encodeUint256(big.NewInt(int64(stock.ItemId)))),
+	}
+	// cId duplicates the cityId key so the value is readable from the record
+	staticData, err := encodeStaticFields("NpcShopInventory", big.NewInt(int64(cityId)), stock.Amount)
+	if err != nil {
+		return nil, err
+	}
+	encodedLength := mud.PackedCounter{}
+	dynamicData := []byte{}
+	mt := mud.NewMudTable("NpcShopInventory", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
@@ -10,7 +10,10 @@ import (
 
 func PetComponentInfoCallData(petId int, petCpn common.PetComponents) ([]byte, error) {
 	// zap.S().Infow("quest.TitleId", "value", quest.AchievementId)
-	staticData := []byte{}
+	staticData, err := encodeStaticFields("PetCpnInfo")
+	if err != nil {
+		return nil, err
+	}
 	if len(petCpn.CpnValues) != len(petCpn.CpnRatios) {
 		return nil, errors.New("invalid pet component: CpnValues and CpnTypes must have the same length")
 	}
@@ -21,10 +24,13 @@ func PetComponentInfoCallData(petId int, petCpn common.PetComponents) ([]byte, e
 	if totalRatio != 10_000 {
 		return nil, errors.New("invalid pet component: total ratio must be 10000")
 	}
-	encodedLength := mud.EncodeLengths([]int{
+	encodedLength, err := mud.EncodeLengths([]int{
 		2 * len(petCpn.CpnValues),
 	})
-	dynamicData, err := encodePacked(petCpn.CpnRatios)
+	if err != nil {
+		return nil, err
+	}
+	dynamicData, err := encodeDynamicFields("PetCpnInfo", petCpn.CpnRatios)
 	if err != nil {
 		return nil, err
 	}
@@ -11,7 +11,7 @@ import (
 
 func QuestCallData(quest common.QuestV4) ([]byte, error) {
 	// zap.S().Infow("quest.TitleId", "value", quest.AchievementId)
-	staticData, err := encodePacked(quest.Exp, quest.Gold, uint8(quest.QuestType),
+	staticData, err := encodeStaticFields("QuestV4", quest.Exp, quest.Gold, uint8(quest.QuestType),
 		big.NewInt(quest.FromNpcId), big.NewInt(quest.ToNpcId), big.NewInt(quest.AchievementId))
 	if err != nil {
 		return nil, err
@@ -22,12 +22,15 @@ func QuestCallData(quest common.QuestV4) ([]byte, error) {
 	if len(quest.RewardItemIds) > 0 {
 		zap.S().Infow("quest data", "quest", quest)
 	}
-	encodedLength := mud.EncodeLengths([]int{
+	encodedLength, err := mud.EncodeLengths([]int{
 		32 * len(quest.RequiredAchievementIds),
 		32 * len(quest.RequiredDoneQuestIds),
 		32 * len(quest.RewardItemIds),
 		4 * len(quest.RewardItemAmounts),
 	})
+	if err != nil {
+		return nil, err
+	}
 	var requiredAchievementIds []*big.Int
 	var requiredDoneQuestIds []*big.Int
 	var rewardItemIds []*big.Int
@@ -40,7 +43,7 @@ func QuestCallData(quest common.QuestV4) ([]byte, error) {
 	for _, itemId := range quest.RewardItemIds {
 		rewardItemIds = append(rewardItemIds, big.NewInt(itemId))
 	}
-	dynamicData, err := encodePacked(requiredAchievementIds, requiredDoneQuestIds, rewardItemIds, quest.RewardItemAmounts)
+	dynamicData, err := encodeDynamicFields("QuestV4", requiredAchievementIds, requiredDoneQuestIds, rewardItemIds, quest.RewardItemAmounts)
 	if err != nil {
 		return nil, err
 	}
@@ -55,9 +58,15 @@ func QuestLocateCallData(questId int64, locations []common.Location) ([]byte, er
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(int64(questId)))),
 	}
-	staticData := []byte{}
+	staticData, err := encodeStaticFields("QuestLocate")
+	if err != nil {
+		return nil, err
+	}
 	lengths := []int{len(locations) * 4, len(locations) * 4}
-	encodedLength := mud.EncodeLengths(lengths)
+	encodedLength, err := mud.EncodeLengths(lengths)
+	if err != nil {
+		return nil, err
+	}
 	var (
 		xs []int32
 		ys []int32
@@ -66,22 +75,23 @@ func QuestLocateCallData(questId int64, locations []common.Location) ([]byte, er
 		xs = append(xs, locations[i].X)
 		ys = append(ys, locations[i].Y)
 	}
-	xsPacked, err := encodePacked(xs)
+	dynamicData, err := encodeDynamicFields("QuestLocate", xs, ys)
 	if err != nil {
 		return nil, err
 	}
-	ysPacked, err := encodePacked(ys)
-	if err != nil {
-		return nil, err
-	}
-	dynamicData := simpleEncodePacked(xsPacked, ysPacked)
 	mt := mud.NewMudTable("QuestLocate", "app", "")
 	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
 }
 
 func QuestContributeCallData(questId int64, details []common.ContributeDetail) ([]byte, error) {
-	staticData := []byte{}
-	encodedLength := mud.EncodeLengths([]int{len(details) * 32, len(details) * 4})
+	staticData, err := encodeStaticFields("QuestContribute")
+	if err != nil {
+		return nil, err
+	}
+	encodedLength, err := mud.EncodeLengths([]int{len(details) * 32, len(details) * 4})
+	if err != nil {
+		return nil, err
+	}
 	var (
 		itemIds []*big.Int
 		amounts []uint32
@@ -91,12 +101,10 @@ func QuestContributeCallData(questId int64, details []common.ContributeDetail) (
 		amounts = append(amounts, i.Amount)
 	}
 
-	itemIdsEncodePacked := encodeUint256Array(itemIds)
-	amountsEncodePacked, err := encodePacked(amounts)
+	dynamicData, err := encodeDynamicFields("QuestContribute", itemIds, amounts)
 	if err != nil {
 		return nil, err
 	}
-	dynamicData := simpleEncodePacked(itemIdsEncodePacked, amountsEncodePacked)
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(questId))),
 	}
@@ -0,0 +1,53 @@
+package table
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/ftk/post-deploy/pkg/mud"
+)
+
+func SalePackageV2CallData(salePackage common.SalePackage) ([]byte, error) {
+	// SaleSystem treats a package without price as not found and ignores goldPrice if crystalPrice is set
+	if (salePackage.CrystalPrice == 0) == (salePackage.GoldPrice == 0) {
+		return nil, fmt.Errorf("invalid sale package %d: exactly one of crystalPrice and goldPrice is required", salePackage.Id)
+	}
+	if len(salePackage.ItemIds) != len(salePackage.ItemAmounts) {
+		return nil, fmt.Errorf("invalid sale package %d: %d itemIds but %d itemAmounts",
+			salePackage.Id, len(salePackage.ItemIds), len(salePackage.ItemAmounts))
+	}
+	achievementIds := make([]*big.Int, 0, len(salePackage.AchievementIds))
+	for _, achievementId := range salePackage.AchievementIds {
+		achievementIds = append(achievementIds, big.NewInt(int64(achievementId)))
+	}
+	itemIds := make([]*big.Int, 0, len(salePackage.ItemIds))
+	for i, itemId := range salePackage.ItemIds {
+		if salePackage.ItemAmounts[i] == 0 {
+			return nil, fmt.Errorf("invalid sale package %d: item %d amount must be positive", salePackage.Id, itemId)
+		}
+		itemIds = append(itemIds, big.NewInt(int64(itemId)))
+	}
+	staticData, err := encodeStaticFields("SalePackageV2",
+		salePackage.CrystalPrice, salePackage.GoldPrice, salePackage.Gold)
+	if err != nil {
+		return nil, err
+	}
+	encodedLength, err := mud.EncodeLengths([]int{
+		32 * len(achievementIds),
+		32 * len(itemIds),
+		4 * len(salePackage.ItemAmounts),
+	})
+	if err != nil {
+		return nil, err
+	}
+	dynamicData, err := encodeDynamicFields("SalePackageV2", achievementIds, itemIds, salePackage.ItemAmounts)
+	if err != nil {
+		return nil, err
+	}
+	keyTuple := [][32]byte{
+		[32]byte(encodeUint256(big.NewInt(int64(salePackage.Id)))),
+	}
+	mt := mud.NewMudTable("SalePackageV2", "app", "")
+	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
+}
@@ -0,0 +1,139 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ftk/post-deploy/pkg/mud"
+)
+
+var (
+	bigIntType  = reflect.TypeOf(&big.Int{})
+	addressType = reflect.TypeOf(ethcommon.Address{})
+	bytesType   = reflect.TypeOf([]byte{})
+)
+
+// encodeStaticFields checks values against the static fields of the table schema
+// (count, order and type) and returns them packed
+func encodeStaticFields(tableName string, values ...interface{}) ([]byte, error) {
+	schema, err := mud.GetTableSchema(tableName)
+	if err != nil {
+		return nil, err
+	}
+	return encodeFields(schema.Name, schema.Static, values)
+}
+
+// encodeDynamicFields checks values against the dynamic fields of the table schema
+// (count, order and element type) and returns them packed
+func encodeDynamicFields(tableName string, values ...interface{}) ([]byte, error) {
+	schema, err := mud.GetTableSchema(tableName)
+	if err != nil {
+		return nil, err
+	}
+	return encodeFields(schema.Name, schema.Dynamic, values)
+}
+
+// encodeDynamicField checks a single dynamic field by index and returns it packed
+func encodeDynamicField(tableName string, index int, value interface{}) ([]byte, error) {
+	schema, err := mud.GetTableSchema(tableName)
+	if err != nil {
+		return nil, err
+	}
+	if index >= len(schema.Dynamic) {
+		return nil, fmt.Errorf("table %s: dynamic field index %d out of range", tableName, index)
+	}
+	return encodeFields(schema.Name, schema.Dynamic[index:index+1], []interface{}{value})
+}
+
+// encodeStaticField checks a single static field by index and returns it packed
+func encodeStaticField(tableName string, index int, value interface{}) ([]byte, error) {
+	schema, err := mud.GetTableSchema(tableName)
+	if err != nil {
+		return nil, err
+	}
+	if index >= len(schema.Static) {
+		return nil, fmt.Errorf("table %s: static field index %d out of range", tableName, index)
+	}
+	return encodeFields(schema.Name, schema.Static[index:index+1], []interface{}{value})
+}
+
+// encodeFields checks and packs values with the solidity types of the fields
+func encodeFields(tableName string, fields []mud.FieldSchema, values []interface{}) ([]byte, error) {
+	if err := checkFields(tableName, fields, values); err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	for i, v := range values {
+		data, err := mud.EncodeValue(fields[i].Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: field %s: %w", tableName, fields[i].Name, err)
+		}
+		buffer.Write(data)
+	}
+	return buffer.Bytes(), nil
+}
+
+func checkFields(tableName string, fields []mud.FieldSchema, values []interface{}) error {
+	if len(values) != len(fields) {
+		return fmt.Errorf("table %s: got %d values, schema has %d fields", tableName, len(values), len(fields))
+	}
+	for i, v := range values {
+		if err := checkFieldType(fields[i], v); err != nil {
+			return fmt.Errorf("table %s: %w", tableName, err)
+		}
+	}
+	return nil
+}
+
+// checkFieldType returns an error if the go value cannot be packed as the field's solidity type
+func checkFieldType(field mud.FieldSchema, value interface{}) error {
+	t := reflect.TypeOf(value)
+	if t == nil {
+		return fmt.Errorf("field %s: nil value", field.Name)
+	}
+	switch {
+	case field.Type == "string":
+		if t.Kind() == reflect.String || t == reflect.TypeOf([]byte{}) {
+			return nil
+		}
+	case field.Type == "bytes":
+		if t == reflect.TypeOf([]byte{}) {
+			return nil
+		}
+	case mud.IsDynamicType(field.Type):
+		elem, _, _ := mud.ArrayElementType(field.Type)
+		if (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && matchStaticType(elem, t.Elem()) {
+			return nil
+		}
+	default:
+		if matchStaticType(field.Type, t) {
+			return nil
+		}
+	}
+	return fmt.Errorf("field %s: cannot pack %s as %s", field.Name, t, field.Type)
+}
+
+func matchStaticType(solType string, t reflect.Type) bool {
+	switch {
+	case t == bigIntType:
+		// go has no integer type wider than 64 bits
+		return mud.StaticByteLength(solType) > 8 && strings.Contains(solType, "int")
+	case t == addressType:
+		return solType == "address"
+	case t == bytesType || (t.Kind() == reflect.Array && t.Elem().Kind() == reflect.Uint8):
+		return strings.HasPrefix(solType, "bytes")
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return solType == "bool"
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return solType == fmt.Sprintf("uint%d", t.Bits())
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return solType == fmt.Sprintf("int%d", t.Bits())
+	}
+	return false
+}
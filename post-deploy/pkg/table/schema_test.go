@@ -0,0 +1,355 @@
+package table
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ftk/post-deploy/pkg/common"
+	"github.com/ftk/post-deploy/pkg/mud"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeRecord decodes setRecord calldata back to field name => value using the table schema
+func decodeRecord(t *testing.T, callData []byte) (string, map[string]string) {
+	tableId, keyTuple, staticData, encodedLengths, dynamicData, err := mud.UnpackSetRecord(callData)
+	require.NoError(t, err)
+	// the resource id keeps 16 bytes of the name, look the schema up by id for longer names
+	schema, err := mud.TableSchemaByID(tableId)
+	require.NoError(t, err)
+	tableName := schema.Name
+
+	result := make(map[string]string)
+	require.Len(t, keyTuple, len(schema.Key))
+	for i, f := range schema.Key {
+		size := mud.StaticByteLength(f.Type)
+		result[f.Name] = fmt.Sprint(mud.DecodeStaticValue(f.Type, keyTuple[i][32-size:]))
+	}
+	staticValues, err := schema.DecodeStaticData(staticData)
+	require.NoError(t, err)
+	for i, f := range schema.Static {
+		result[f.Name] = fmt.Sprint(staticValues[i])
+	}
+	dynamicValues, err := schema.DecodeDynamicData(encodedLengths, dynamicData)
+	require.NoError(t, err)
+	for i, f := range schema.Dynamic {
+		result[f.Name] = fmt.Sprint(dynamicValues[i])
+	}
+	return tableName, result
+}
+
+func TestCallDataMatchesSchema(t *testing.T) {
+	tests := []struct {
+		table string
+		build func() ([]byte, error)
+		want  map[string]string
+	}{
+		{
+			table: "Achievement",
+			build: func() ([]byte, error) {
+				return AchievementCallData(common.Achievement{Id: 7, Name: "Hero", Stats: common.AchievementStats{Atk: 1, Def: 2, Agi: 3}})
+			},
+			want: map[string]string{"id": "7", "atk": "1", "def": "2", "agi": "3", "name": "Hero"},
+		},
+		{
+			table: "EquipmentInfo2V2",
+			build: func() ([]byte, error) {
+				return EquipmentItemInfo2V2CallData(common.EquipmentInfo{SlotType: 5, BonusWeight: 50, MaxLevel: 4, Counter: 2, DmgPercent: 150}, 34)
+			},
+			want: map[string]string{"itemId": "34", "maxLevel": "4", "counter": "2", "dmgPercent": "150", "bonusWeight": "50", "shieldBarrier": "0"},
+		},
+		{
+			table: "ItemV2",
+			build: func() ([]byte, error) {
+				return ItemCallData(common.Item{Id: 9, Category: 1, Type: 6, Weight: 300, Tier: 4, Untradable: true, Name: "Sword"})
+			},
+			want: map[string]string{"id": "9", "category": "1", "itemType": "6", "weight": "300", "tier": "4", "isUntradeable": "true", "name": "Sword"},
+		},
+		{
+			table: "EquipmentInfo",
+			build: func() ([]byte, error) {
+				return EquipmentItemInfoCallData(common.EquipmentInfo{
+					SlotType: 1, AdvantageType: 2, TwoHanded: true, Hp: 3, Atk: 4, Def: 5, Agi: 6, Ms: 7}, 10)
+			},
+			want: map[string]string{"itemId": "10", "slotType": "1", "advantageType": "2", "twoHanded": "true",
+				"hp": "3", "atk": "4", "def": "5", "agi": "6", "ms": "7"},
+		},
+		{
+			table: "EquipmentInfo2V2",
+			build: func() ([]byte, error) {
+				return EquipmentItemInfo2V2CallData(common.EquipmentInfo{BonusWeight: 11, ShieldBarrier: 12}, 10)
+			},
+			want: map[string]string{"itemId": "10", "maxLevel": "0", "counter": "0", "dmgPercent": "0", "bonusWeight": "11", "shieldBarrier": "12"},
+		},
+		{
+			table: "CardInfo",
+			build: func() ([]byte, error) {
+				return CardInfoCallData(common.CardInfo{Top: 1, Bottom: 2, Left: 3, Right: 4}, 14)
+			},
+			want: map[string]string{"itemId": "14", "top": "1", "left": "3", "right": "4", "bottom": "2"},
+		},
+		{
+			table: "BuffItemInfoV3",
+			build: func() ([]byte, error) {
+				return BuffItemInfoCallData(common.BuffItemInfo{
+					Type: 2, Range: 3, Duration: 4, SelfCastOnly: true, NumTarget: 5, IsBuff: true}, 11)
+			},
+			want: map[string]string{"itemId": "11", "range": "3", "duration": "4", "numTarget": "5",
+				"selfCastOnly": "true", "buffType": "2", "isBuff": "true"},
+		},
+		{
+			table: "BuffStatV4",
+			build: func() ([]byte, error) {
+				return BuffStatCallData(common.StatsModify{
+					AtkPercent: -1, DefPercent: 2, AgiPercent: -3, Sp: -4, Ms: 5, SlowPercent: 6, Dmg: 7, IsAbsDmg: true}, 12)
+			},
+			want: map[string]string{"itemId": "12", "atkPercent": "-1", "defPercent": "2", "agiPercent": "-3",
+				"sp": "-4", "ms": "5", "slowPercent": "6", "dmg": "7", "isAbsDmg": "true"},
+		},
+		{
+			table: "BuffExp",
+			build: func() ([]byte, error) {
+				return BuffExpCallData(common.ExpAmplify{FarmingPerkAmp: 110, PveExpAmp: 120, PvePerkAmp: 130}, 13)
+			},
+			want: map[string]string{"itemId": "13", "farmingPerkAmp": "110", "pveExpAmp": "120", "pvePerkAmp": "130"},
+		},
+		{
+			table: "City",
+			build: func() ([]byte, error) {
+				return CityCallData(common.City{Id: 2, X: -10, Y: 20, KingdomId: 3, Name: "Town", IsCapital: true, Level: 4})
+			},
+			want: map[string]string{"id": "2", "x": "-10", "y": "20", "isCapital": "true", "kingdomId": "3", "level": "4", "name": "Town"},
+		},
+		{
+			table: "CResourceRequire",
+			build: func() ([]byte, error) {
+				return CResourceRequireCallData(common.CityLevelRequire{Level: 2,
+					Resources: []common.Ingredient{{ItemId: 2, Amount: 1000}, {ItemId: 7, Amount: 500}}})
+			},
+			want: map[string]string{"level": "2", "resourceIds": "[2 7]", "amounts": "[1000 500]"},
+		},
+		{
+			table: "CrystalFee",
+			build: func() ([]byte, error) {
+				return CrystalFeeCallData(4, 10)
+			},
+			want: map[string]string{"kingdomId": "4", "fee": "10"},
+		},
+		{
+			table: "ExpAmpConfig",
+			build: func() ([]byte, error) {
+				return ExpAmpConfigCallData(common.ExpAmpConfig{FarmingPerkAmp: 1, PveExpAmp: 2, PvePerkAmp: 3, ExpireTime: 1767225600})
+			},
+			want: map[string]string{"farmingPerkAmp": "1", "pveExpAmp": "2", "pvePerkAmp": "3", "expireTime": "1767225600"},
+		},
+		{
+			table: "GachaV5",
+			build: func() ([]byte, error) {
+				return GachaV5CallData(common.Gacha{Id: 2, StartTime: 1700000000, TicketItemId: 437,
+					Items: []common.GachaItem{{ItemId: 436, Amount: 1, Percent: 100}, {ItemId: 268, Amount: 3, Percent: 9900}}})
+			},
+			want: map[string]string{"id": "2", "startTime": "1700000000", "ticketValue": "0", "ticketItemId": "437",
+				"itemIds": "[436 268]", "amounts": "[1 3]", "percents": "[100 9900]"},
+		},
+		{
+			table: "InventoryConfig",
+			build: func() ([]byte, error) {
+				return InventoryConfigCallData(common.InventoryConfig{BaseWeight: 200, MaxWeight: 1000})
+			},
+			want: map[string]string{"baseWeight": "200", "maxWeight": "1000"},
+		},
+		{
+			table: "KingSetting",
+			build: func() ([]byte, error) {
+				return KingSettingCallData(2, common.KingdomEconomy{PvpFamePenalty: 10, CaptureTilePenalty: 20})
+			},
+			want: map[string]string{"kingdomId": "2", "pvpFamePenalty": "10", "captureTilePenalty": "20"},
+		},
+		{
+			table: "KingSetting2",
+			build: func() ([]byte, error) {
+				return KingSetting2CallData(2, common.KingdomEconomy{WithdrawWeightLimit: 1000})
+			},
+			want: map[string]string{"kingdomId": "2", "withdrawWeightLimit": "1000"},
+		},
+		{
+			table: "MarketFee",
+			build: func() ([]byte, error) {
+				return MarketFeeCallData(1, 3, 5)
+			},
+			want: map[string]string{"kingdomAId": "1", "kingdomBId": "3", "fee": "5"},
+		},
+		{
+			table: "MapConfig",
+			build: func() ([]byte, error) {
+				return MapConfigCallData(147, 117)
+			},
+			want: map[string]string{"width": "147", "height": "117"},
+		},
+		{
+			table: "Monster",
+			build: func() ([]byte, error) {
+				return MonsterCallData(common.Monster{Id: 5, Name: "Wolf", Grow: 1, Exp: 2, PerkExp: 3, IsBoss: true,
+					SkillIds: []int{4, 5, 0, 0, 0}, ItemIds: []int{6}, ItemAmounts: []int{7}})
+			},
+			want: map[string]string{"id": "5", "grow": "1", "exp": "2", "perkExp": "3", "isBoss": "true",
+				"name": "Wolf", "skillIds": "[4 5 0 0 0]", "itemIds": "[6]", "itemAmounts": "[7]"},
+		},
+		{
+			table: "MonsterLocation",
+			build: func() ([]byte, error) {
+				return MonsterLocationCallData(common.Location{X: -1, Y: 2}, 3, 4, 1)
+			},
+			want: map[string]string{"x": "-1", "y": "2", "monsterId": "3", "level": "4", "advantageType": "1"},
+		},
+		{
+			table: "MovementConfig",
+			build: func() ([]byte, error) {
+				return MovementConfigCallData(common.MovementConfig{BaseMovementSpeed: 1, MaxMovementSpeed: 20, Duration: 8})
+			},
+			want: map[string]string{"baseMovementSpeed": "1", "maxMovementSpeed": "20", "duration": "8"},
+		},
+		{
+			table: "NonOccupyTile",
+			build: func() ([]byte, error) {
+				return NonOccupyTileCallData(common.Location{X: 71, Y: -2})
+			},
+			want: map[string]string{"x": "71", "y": "-2", "value": "true"},
+		},
+		{
+			table: "NpcShopInventory",
+			build: func() ([]byte, error) {
+				return NpcShopInventoryCallData(4, common.NpcShopStock{ItemId: 268, Amount: 5})
+			},
+			want: map[string]string{"cityId": "4", "itemId": "268", "cId": "4", "amount": "5"},
+		},
+		{
+			table: "QuestV4",
+			build: func() ([]byte, error) {
+				return QuestCallData(common.QuestV4{Id: 1, Exp: 2, Gold: 3, QuestType: 1, FromNpcId: 4, ToNpcId: 5, AchievementId: 6,
+					RequiredAchievementIds: []int64{7}, RequiredDoneQuestIds: []int64{8}, RewardItemIds: []int64{9}, RewardItemAmounts: []uint32{10}})
+			},
+			want: map[string]string{"id": "1", "exp": "2", "gold": "3", "questType": "1", "fromNpcId": "4", "toNpcId": "5",
+				"achievementId": "6", "requiredAchievementIds": "[7]", "requiredDoneQuestIds": "[8]",
+				"rewardItemIds": "[9]", "rewardItemAmounts": "[10]"},
+		},
+		{
+			table: "QuestLocate",
+			build: func() ([]byte, error) {
+				return QuestLocateCallData(1, []common.Location{{X: -2, Y: 3}})
+			},
+			want: map[string]string{"questId": "1", "xs": "[-2]", "ys": "[3]"},
+		},
+		{
+			table: "RestrictLocV2",
+			build: func() ([]byte, error) {
+				return RestrictLocV2CallData(common.Location{X: -1, Y: 2}, 3)
+			},
+			want: map[string]string{"x": "-1", "y": "2", "cityId": "3", "isRestricted": "true"},
+		},
+		{
+			table: "SalePackageV2",
+			build: func() ([]byte, error) {
+				return SalePackageV2CallData(common.SalePackage{Id: 1, CrystalPrice: 100, Gold: 1000,
+					AchievementIds: []int{3}, ItemIds: []int{437, 118}, ItemAmounts: []uint32{1, 10}})
+			},
+			want: map[string]string{"id": "1", "crystalPrice": "100", "goldPrice": "0", "gold": "1000",
+				"achievementIds": "[3]", "itemIds": "[437 118]", "itemAmounts": "[1 10]"},
+		},
+		{
+			table: "SkillV2",
+			build: func() ([]byte, error) {
+				return SkillCallData(common.Skill{Id: 1, Name: "Slash", Sp: 2, Damage: 3, HasEffect: true,
+					PerkItemTypes: []int{4}, RequiredPerkLevels: []int{6}})
+			},
+			want: map[string]string{"id": "1", "sp": "2", "damage": "3", "hasEffect": "true", "name": "Slash",
+				"perkItemTypes": "[4]", "requiredPerkLevels": "[5]"},
+		},
+		{
+			table: "StatModifierItemInfo",
+			build: func() ([]byte, error) {
+				return StatModifierItemInfoCallData(common.StatModifierItemInfo{Duration: 600, AtkPercent: 10, DefPercent: -5, Ms: -1}, 470)
+			},
+			want: map[string]string{"itemId": "470", "duration": "600", "atkPercent": "10", "defPercent": "-5", "agiPercent": "0", "ms": "-1"},
+		},
+		{
+			table: "TileInfo3",
+			build: func() ([]byte, error) {
+				return TileInfoCallData(common.TileInfo{KingdomId: 1, X: -3, Y: 4, ZoneType: 2, ResourceItemIds: []int64{5}}, common.DataConfig{})
+			},
+			want: map[string]string{"x": "-3", "y": "4", "kingdomId": "1", "farmSlot": "3", "zoneType": "2",
+				"occupiedTime": "0", "replenishTime": "0", "itemIds": "[5]", "farmingQuotas": "[]", "monsterIds": "[]"},
+		},
+		{
+			table: "Unmovable",
+			build: func() ([]byte, error) {
+				return UnmovableCallData(common.Location{X: -73, Y: 57})
+			},
+			want: map[string]string{"x": "-73", "y": "57", "value": "true"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.table, func(t *testing.T) {
+			callData, err := tc.build()
+			require.NoError(t, err)
+			tableName, values := decodeRecord(t, callData)
+			require.Equal(t, tc.table, tableName)
+			require.Equal(t, tc.want, values)
+		})
+	}
+}
+
+func TestAllianceV2CallDataWritesBothKeyOrders(t *testing.T) {
+	callData, err := AllianceV2CallData(3, 4)
+	require.NoError(t, err)
+	require.Len(t, callData, 2)
+	for i, keys := range [][2]string{{"3", "4"}, {"4", "3"}} {
+		tableName, values := decodeRecord(t, callData[i])
+		require.Equal(t, "AllianceV2", tableName)
+		require.Equal(t, map[string]string{"kingdomA": keys[0], "kingdomB": keys[1], "isAlliance": "true", "isApproved": "true"}, values)
+	}
+	_, err = AllianceV2CallData(2, 2)
+	require.Error(t, err)
+}
+
+func TestEncodeStaticFieldsRejectsMismatch(t *testing.T) {
+	_, err := encodeStaticFields("BuffExp", uint16(1), uint16(2))
+	require.Error(t, err)
+	_, err = encodeStaticFields("BuffExp", uint16(1), uint32(2), uint16(3))
+	require.Error(t, err)
+	_, err = encodeDynamicFields("CollectionExcV2", []uint32{1}, []uint32{2})
+	require.Error(t, err)
+	// fixed arrays must have exactly N elements
+	_, err = encodeDynamicField("Monster", 1, []*big.Int{big.NewInt(1)})
+	require.Error(t, err)
+	_, err = CardInfoCallData(common.CardInfo{Top: -1, Bottom: 2, Left: 3, Right: 4}, 14)
+	require.Error(t, err)
+	_, err = CardInfoCallData(common.CardInfo{Top: 1, Bottom: 1 << 16, Left: 3, Right: 4}, 14)
+	require.Error(t, err)
+	gachaItems := []common.GachaItem{{ItemId: 436, Amount: 1, Percent: 100}, {ItemId: 268, Amount: 3, Percent: 9800}}
+	_, err = GachaV5CallData(common.Gacha{Id: 2, TicketItemId: 437, Items: gachaItems})
+	require.Error(t, err)
+	gachaItems[1].Percent = 9900
+	_, err = GachaV5CallData(common.Gacha{Id: 2, Items: gachaItems})
+	require.Error(t, err)
+	_, err = MarketFeeCallData(1, 2, 101)
+	require.Error(t, err)
+	_, err = CrystalFeeCallData(1, 11)
+	require.Error(t, err)
+	_, err = InventoryConfigCallData(common.InventoryConfig{BaseWeight: 300, MaxWeight: 200})
+	require.Error(t, err)
+	_, err = MovementConfigCallData(common.MovementConfig{BaseMovementSpeed: 1, MaxMovementSpeed: 20})
+	require.Error(t, err)
+	_, err = CResourceRequireCallData(common.CityLevelRequire{Level: 4})
+	require.Error(t, err)
+	_, err = SalePackageV2CallData(common.SalePackage{Id: 1, CrystalPrice: 100, GoldPrice: 500})
+	require.Error(t, err)
+	_, err = SalePackageV2CallData(common.SalePackage{Id: 1, GoldPrice: 500, ItemIds: []int{118}})
+	require.Error(t, err)
+	_, err = EquipmentItemInfo2V2CallData(common.EquipmentInfo{SlotType: 7, MaxLevel: 4}, 34)
+	require.Error(t, err)
+	_, err = StatModifierItemInfoCallData(common.StatModifierItemInfo{AtkPercent: 10}, 470)
+	require.Error(t, err)
+	_, err = encodeStaticFields("NotATable")
+	require.Error(t, err)
+}
@@ -12,7 +12,7 @@ func SkillCallData(skill common.Skill) ([]byte, error) {
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(int64(skill.Id)))),
 	}
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticFields("SkillV2",
 		uint8(skill.Sp),
 		uint16(skill.Damage),
 		skill.HasEffect,
@@ -20,10 +20,13 @@ func SkillCallData(skill common.Skill) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	encodedLength := mud.EncodeLengths([]int{
+	encodedLength, err := mud.EncodeLengths([]int{
 		len(stringToBytes(skill.Name)),
 		len(skill.PerkItemTypes),
 		len(skill.RequiredPerkLevels)})
+	if err != nil {
+		return nil, err
+	}
 	if len(skill.PerkItemTypes) != len(skill.RequiredPerkLevels) {
 		panic("invalid perk and perk level len in skill")
 	}
@@ -39,11 +42,10 @@ func SkillCallData(skill common.Skill) ([]byte, error) {
 		}
 		scRequiredPerkLevels = append(scRequiredPerkLevels, uint8(rpl))
 	}
-	rawDynamicData, err := encodePacked(scPerkItemTypes, scRequiredPerkLevels)
+	dynamicData, err := encodeDynamicFields("SkillV2", stringToBytes(skill.Name), scPerkItemTypes, scRequiredPerkLevels)
 	if err != nil {
 		return nil, err
 	}
-	dynamicData := simpleEncodePacked(stringToBytes(skill.Name), rawDynamicData)
 	mt := mud.NewMudTable("SkillV2", "app", "")
 	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
 }
@@ -52,7 +54,7 @@ func SkillEffectCallData(skillID int, skillEffect common.SkillEffect) ([]byte, e
 	keyTuple := [][32]byte{
 		[32]byte(encodeUint256(big.NewInt(int64(skillID)))),
 	}
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticFields("SkillEffect",
 		uint8(skillEffect.EffectType),
 		uint16(skillEffect.Damage),
 		uint8(skillEffect.Turns),
@@ -8,10 +8,6 @@ import (
 	"go.uber.org/zap"
 )
 
-var (
-	tileInfoFL = "0x0043050301010120200000000000000000000000000000000000000000000000"
-)
-
 func TileInfoCallData(ti common.TileInfo, dataConfig common.DataConfig) ([]byte, error) {
 	l := zap.S()
 	keyTuple := [][32]byte{
@@ -28,7 +24,7 @@ func TileInfoCallData(ti common.TileInfo, dataConfig common.DataConfig) ([]byte,
 			}
 		}
 	}
-	staticData, err := encodePacked(
+	staticData, err := encodeStaticFields("TileInfo3",
 		ti.KingdomId,
 		farmSlot,
 		ti.ZoneType,
@@ -38,14 +34,19 @@ func TileInfoCallData(ti common.TileInfo, dataConfig common.DataConfig) ([]byte,
 	if err != nil {
 		return nil, err
 	}
-	encodedLength := mud.EncodeLengths([]int{len(ti.ResourceItemIds) * 32, 0, 0})
+	encodedLength, err := mud.EncodeLengths([]int{len(ti.ResourceItemIds) * 32, 0, 0})
+	if err != nil {
+		return nil, err
+	}
 	resourceIds := make([]*big.Int, 0)
 	for _, rId := range ti.ResourceItemIds {
 		resourceIds = append(resourceIds, big.NewInt(rId))
 	}
-	resourceIdsData := encodeUint256Array(resourceIds)
-	dynamicData := simpleEncodePacked(resourceIdsData, encodeUint256Array(nil), encodeUint256Array(nil))
-	mt := mud.NewMudTable("TileInfo3", "app", tileInfoFL)
+	dynamicData, err := encodeDynamicFields("TileInfo3", resourceIds, []uint16{}, []*big.Int{})
+	if err != nil {
+		return nil, err
+	}
+	mt := mud.NewMudTable("TileInfo3", "app", "")
 	return mt.SetRecordRawCalldata(keyTuple, staticData, encodedLength, dynamicData)
 }
 
@@ -54,11 +55,11 @@ func TileInfoSetZoneCallData(ti common.TileInfo, zone uint8) ([]byte, error) {
 		[32]byte(encodeUint256(big.NewInt(int64(ti.X)))),
 		[32]byte(encodeUint256(big.NewInt(int64(ti.Y)))),
 	}
-	staticData, err := encodePacked(zone)
+	staticData, err := encodeStaticField("TileInfo3", 2, zone)
 	if err != nil {
 		return nil, err
 	}
-	mt := mud.NewMudTable("TileInfo3", "app", tileInfoFL)
+	mt := mud.NewMudTable("TileInfo3", "app", "")
 	return mt.SetStaticFieldRawCalldata(keyTuple, 2, staticData)
 }
 
@@ -71,7 +72,10 @@ func TileInfoSetResourceCallData(ti common.TileInfo, resources []int64) ([]byte,
 	for _, r := range resources {
 		bigResources = append(bigResources, big.NewInt(r))
 	}
-	dynamicData := encodeUint256Array(bigResources)
-	mt := mud.NewMudTable("TileInfo3", "app", tileInfoFL)
+	dynamicData, err := encodeDynamicField("TileInfo3", 0, bigResources)
+	if err != nil {
+		return nil, err
+	}
+	mt := mud.NewMudTable("TileInfo3", "app", "")
 	return mt.SetDynamicFieldRawCalldata(keyTuple, 0, dynamicData)
 }
@@ -13,7 +13,10 @@ func WelcomeConfigCallData(welcomeConfig common.WelcomeConfig) ([]byte, error) {
 	for _, itemId := range welcomeConfig.ItemIds {
 		itemIds = append(itemIds, big.NewInt(int64(itemId)))
 	}
-	dynamicData := encodeUint256Array(itemIds)
+	dynamicData, err := encodeDynamicField("WelcomeConfig", 0, itemIds)
+	if err != nil {
+		return nil, err
+	}
 	mt := mud.NewMudTable("WelcomeConfig", "app", "")
 	return mt.SetDynamicFieldRawCalldata(keyTuple, 0, dynamicData)
 }
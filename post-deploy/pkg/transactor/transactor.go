@@ -2,17 +2,25 @@ package transactor
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
 	gblockchain "github.com/NNagato/common/blockchain"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"go.uber.org/zap"
 )
 
+// gasLimitMarginPercent is added to the estimated gas of a transaction, the state may change
+// between the estimate and the execution
+const gasLimitMarginPercent = 20
+
 type Transactor struct {
 	l               *zap.SugaredLogger
 	eClient         *ethclient.Client
@@ -73,18 +81,32 @@ func (t *Transactor) Execute(callData [][]byte, markIndex int) error {
 	}
 	l.Infow("running data", "nonce", nonce, "len calldata", len(callData))
 	counter := 0
-	callData = callData[markIndex:]
 	for {
-		if err := func() error {
+		// retry is false when the error would come back on every retry
+		retry, err := func() (bool, error) {
 			for index := markIndex; index < len(callData); index++ {
 				l.Infow("markIndex", "value", markIndex)
 				counter++
+				gas, err := t.eClient.EstimateGas(context.Background(), ethereum.CallMsg{
+					From: t.txOpts.From,
+					To:   &t.contractAddress,
+					Data: callData[index],
+				})
+				if err != nil {
+					var rpcErr rpc.Error
+					if errors.As(err, &rpcErr) {
+						// the node answered, the line reverts and would revert again
+						return false, fmt.Errorf("line %d: cannot estimate gas: %w", index+1, err)
+					}
+					l.Errorw("cannot estimate gas", "line", index+1, "err", err)
+					return true, err
+				}
 				rawTx := types.NewTx(&types.DynamicFeeTx{
 					ChainID:   t.chainID,
 					Nonce:     nonce,
 					GasTipCap: gblockchain.GweiToWei(0.000000005),
 					GasFeeCap: gblockchain.GweiToWei(0.00000001),
-					Gas:       500_000,
+					Gas:       gas * (100 + gasLimitMarginPercent) / 100,
 					To:        &t.contractAddress,
 					Value:     big.NewInt(0),
 					Data:      callData[index],
@@ -92,16 +114,13 @@ func (t *Transactor) Execute(callData [][]byte, markIndex int) error {
 				signedTx, err := t.txOpts.Signer(t.txOpts.From, rawTx)
 				if err != nil {
 					l.Errorw("cannot sign tx", "err", err)
-					return err
+					return true, err
 				}
 				if err := t.eClient.SendTransaction(context.Background(), signedTx); err != nil {
 					l.Errorw("cannot send transaction", "err", err)
-					return err
-				}
-				l.Infow("send transaction successfully", "tx", signedTx.Hash().Hex())
-				if index == len(callData)-1 {
-					panic("DONE!")
+					return true, err
 				}
+				l.Infow("send transaction successfully", "tx", signedTx.Hash().Hex(), "gas", signedTx.Gas())
 				markIndex++
 				nonce++
 				time.Sleep(time.Second)
@@ -110,15 +129,21 @@ func (t *Transactor) Execute(callData [][]byte, markIndex int) error {
 					time.Sleep(10 * time.Second)
 				}
 			}
+			return false, nil
+		}()
+		if err == nil {
 			return nil
-		}(); err != nil {
-			l.Errorw("cannot send transaction", "err", err)
-			time.Sleep(5 * time.Second)
-			nonce, err = t.eClient.NonceAt(context.Background(), t.txOpts.From, nil)
-			if err != nil {
-				l.Panicw("cannot get nonce", "err", err)
-			}
-			time.Sleep(time.Second)
 		}
+		if !retry {
+			l.Errorw("stop sending", "markIndex", markIndex, "err", err)
+			return err
+		}
+		l.Errorw("cannot send transaction", "err", err)
+		time.Sleep(5 * time.Second)
+		nonce, err = t.eClient.NonceAt(context.Background(), t.txOpts.From, nil)
+		if err != nil {
+			l.Panicw("cannot get nonce", "err", err)
+		}
+		time.Sleep(time.Second)
 	}
 }
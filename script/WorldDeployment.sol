@@ -19,6 +19,7 @@ import { IWorld } from "@codegen/world/IWorld.sol";
 // import { RESOURCE_SYSTEM } from "@latticexyz/world/src/worldResourceTypes.sol";
 import { SpawnSystem } from "@src/systems/SpawnSystem.sol";
 import { GachaSystem } from "@src/systems/GachaSystem.sol";
+import { PostDeploySystem } from "@src/systems/PostDeploySystem.sol";
 import { SystemUtils } from "@utils/SystemUtils.sol";
 import { Script } from "forge-std/Script.sol";
 
@@ -38,6 +39,9 @@ contract WorldDeployment is Script {
     // register GachaSystem as root system
     _registerGachaSystem(world);
 
+    // register PostDeploySystem as root system, it receives the batched post deploy calldata
+    _registerPostDeploySystem(world);
+
     // register Hooks
     HookDeployment.registerHooks(worldAddress);
 
@@ -92,6 +96,14 @@ contract WorldDeployment is Script {
     );
   }
 
+  function _registerPostDeploySystem(IWorld world) private {
+    ResourceId systemId = SystemUtils.getRootSystemId("PostDeploySystem");
+    PostDeploySystem postDeploySystem = new PostDeploySystem();
+
+    // called through batchCall with the system id only, no function selector to register
+    world.registerSystem(systemId, postDeploySystem, true);
+  }
+
   function _registerCharacterNFT(address worldAddress) private {
     IWorld world = IWorld(worldAddress);
     // install puppet module
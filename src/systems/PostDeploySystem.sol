@@ -0,0 +1,119 @@
+pragma solidity >=0.8.24;
+
+import { System } from "@latticexyz/world/src/System.sol";
+import { ResourceId } from "@latticexyz/store/src/ResourceId.sol";
+import { EncodedLengths } from "@latticexyz/store/src/EncodedLengths.sol";
+import { FieldLayout } from "@latticexyz/store/src/FieldLayout.sol";
+import { StoreCore } from "@latticexyz/store/src/StoreCore.sol";
+import { AccessControl } from "@latticexyz/world/src/AccessControl.sol";
+
+/// @dev Root system receiving the store calls of the post-deploy tool packed in World.batchCall,
+/// functions have the World store signatures so that a post-deploy line is forwarded unchanged.
+/// Like the World, the caller must have access to the table.
+contract PostDeploySystem is System {
+  function setRecord(
+    ResourceId tableId,
+    bytes32[] calldata keyTuple,
+    bytes calldata staticData,
+    EncodedLengths encodedLengths,
+    bytes calldata dynamicData
+  )
+    public
+  {
+    AccessControl.requireAccess(tableId, _msgSender());
+    StoreCore.setRecord(tableId, keyTuple, staticData, encodedLengths, dynamicData);
+  }
+
+  function spliceStaticData(
+    ResourceId tableId,
+    bytes32[] calldata keyTuple,
+    uint48 start,
+    bytes calldata data
+  )
+    public
+  {
+    AccessControl.requireAccess(tableId, _msgSender());
+    StoreCore.spliceStaticData(tableId, keyTuple, start, data);
+  }
+
+  function spliceDynamicData(
+    ResourceId tableId,
+    bytes32[] calldata keyTuple,
+    uint8 dynamicFieldIndex,
+    uint40 startWithinField,
+    uint40 deleteCount,
+    bytes calldata data
+  )
+    public
+  {
+    AccessControl.requireAccess(tableId, _msgSender());
+    StoreCore.spliceDynamicData(tableId, keyTuple, dynamicFieldIndex, startWithinField, deleteCount, data);
+  }
+
+  function setField(
+    ResourceId tableId,
+    bytes32[] calldata keyTuple,
+    uint8 fieldIndex,
+    bytes calldata data,
+    FieldLayout fieldLayout
+  )
+    public
+  {
+    AccessControl.requireAccess(tableId, _msgSender());
+    StoreCore.setField(tableId, keyTuple, fieldIndex, data, fieldLayout);
+  }
+
+  function setStaticField(
+    ResourceId tableId,
+    bytes32[] calldata keyTuple,
+    uint8 fieldIndex,
+    bytes calldata data,
+    FieldLayout fieldLayout
+  )
+    public
+  {
+    AccessControl.requireAccess(tableId, _msgSender());
+    StoreCore.setStaticField(tableId, keyTuple, fieldIndex, data, fieldLayout);
+  }
+
+  function setDynamicField(
+    ResourceId tableId,
+    bytes32[] calldata keyTuple,
+    uint8 dynamicFieldIndex,
+    bytes calldata data
+  )
+    public
+  {
+    AccessControl.requireAccess(tableId, _msgSender());
+    StoreCore.setDynamicField(tableId, keyTuple, dynamicFieldIndex, data);
+  }
+
+  function pushToDynamicField(
+    ResourceId tableId,
+    bytes32[] calldata keyTuple,
+    uint8 dynamicFieldIndex,
+    bytes calldata dataToPush
+  )
+    public
+  {
+    AccessControl.requireAccess(tableId, _msgSender());
+    StoreCore.pushToDynamicField(tableId, keyTuple, dynamicFieldIndex, dataToPush);
+  }
+
+  function popFromDynamicField(
+    ResourceId tableId,
+    bytes32[] calldata keyTuple,
+    uint8 dynamicFieldIndex,
+    uint256 byteLengthToPop
+  )
+    public
+  {
+    AccessControl.requireAccess(tableId, _msgSender());
+    StoreCore.popFromDynamicField(tableId, keyTuple, dynamicFieldIndex, byteLengthToPop);
+  }
+
+  function deleteRecord(ResourceId tableId, bytes32[] calldata keyTuple) public {
+    AccessControl.requireAccess(tableId, _msgSender());
+    StoreCore.deleteRecord(tableId, keyTuple);
+  }
+}
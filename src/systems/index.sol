@@ -15,3 +15,4 @@ import { PvPSystem } from "./app/PvPSystem.sol";
 import { DropSystem } from "./app/DropSystem.sol";
 import { DailyQuestSystem } from "./app/DailyQuestSystem.sol";
 import { GachaSystem } from "./GachaSystem.sol"; // registered as root system
+import { PostDeploySystem } from "./PostDeploySystem.sol"; // registered as root system
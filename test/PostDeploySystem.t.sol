@@ -0,0 +1,57 @@
+pragma solidity >=0.8.24;
+
+import { ResourceId } from "@latticexyz/store/src/ResourceId.sol";
+import { EncodedLengths } from "@latticexyz/store/src/EncodedLengths.sol";
+import { SystemCallData } from "@latticexyz/world/src/modules/init/types.sol";
+import { MapConfig, MapConfigData } from "@codegen/index.sol";
+import { PostDeploySystem } from "@src/systems/PostDeploySystem.sol";
+import { SystemUtils } from "@utils/SystemUtils.sol";
+import { WorldFixture } from "@fixtures/index.sol";
+import { TestHelper } from "./TestHelper.sol";
+
+contract PostDeploySystemTest is WorldFixture {
+  function setUp() public override {
+    WorldFixture.setUp();
+  }
+
+  function test_BatchCallShouldWriteRecords() external doPrank(worldDeployer) {
+    world.batchCall(_mapConfigCalls(10, 20));
+
+    MapConfigData memory mapConfigData = MapConfig.get();
+    assertEq(mapConfigData.width, 10);
+    assertEq(mapConfigData.height, 20);
+  }
+
+  function testFuzz_UserShouldNotWriteThroughBatchCall(address user) external {
+    vm.assume(user != worldDeployer);
+    vm.assume(user != creator);
+    vm.assume(user != address(0));
+
+    SystemCallData[] memory calls = _mapConfigCalls(10, 20);
+    vm.startPrank(user);
+    vm.expectRevert(TestHelper.getAccessDeniedError(user, MapConfig._tableId));
+    world.batchCall(calls);
+    vm.stopPrank();
+  }
+
+  /// @dev setRecord then setStaticField of the height, like a post-deploy batch
+  function _mapConfigCalls(uint32 width, uint32 height) private pure returns (SystemCallData[] memory calls) {
+    ResourceId systemId = SystemUtils.getRootSystemId("PostDeploySystem");
+    bytes32[] memory keyTuple = new bytes32[](0);
+    (bytes memory staticData, EncodedLengths encodedLengths, bytes memory dynamicData) = MapConfig.encode(width, 0);
+    calls = new SystemCallData[](2);
+    calls[0] = SystemCallData({
+      systemId: systemId,
+      callData: abi.encodeCall(
+        PostDeploySystem.setRecord, (MapConfig._tableId, keyTuple, staticData, encodedLengths, dynamicData)
+      )
+    });
+    calls[1] = SystemCallData({
+      systemId: systemId,
+      callData: abi.encodeCall(
+        PostDeploySystem.setStaticField,
+        (MapConfig._tableId, keyTuple, 1, abi.encodePacked(height), MapConfig._fieldLayout)
+      )
+    });
+  }
+}